@@ -0,0 +1,91 @@
+package dsdm
+
+import (
+	"context"
+
+	"github.com/csnewman/dyndirect/go/internal"
+	"github.com/google/uuid"
+)
+
+type AccountResponse = internal.AccountResponse
+
+type AccountSubdomain = internal.AccountSubdomain
+
+type AccountSubdomainsResponse = internal.AccountSubdomainsResponse
+
+type AccountRevokeResponse = internal.AccountRevokeResponse
+
+type AccountSubdomainRequest struct {
+	// Scheme defaults to the server configured scheme when empty.
+	Scheme      SubdomainScheme
+	Description string
+}
+
+// WithAccountKey returns a copy of the client that authenticates with an
+// account key. Subdomains owned by the account can then be managed with an
+// empty Token in requests.
+func (c *Client) WithAccountKey(key string) *Client {
+	clone := *c
+	clone.accountKey = key
+
+	return &clone
+}
+
+func (c *Client) CreateAccount(ctx context.Context, name string) (*AccountResponse, error) {
+	body := internal.CreateAccountRequest{}
+
+	if name != "" {
+		body.Name = &name
+	}
+
+	resp, err := c.v1.CreateAccount(ctx, body, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[AccountResponse](resp)
+}
+
+func (c *Client) AllocateAccountSubdomain(
+	ctx context.Context,
+	req AccountSubdomainRequest,
+) (*SubdomainResponse, error) {
+	params := &internal.AccountAllocateSubdomainParams{}
+
+	if req.Scheme != "" {
+		params.Scheme = &req.Scheme
+	}
+
+	body := internal.AccountSubdomainRequest{}
+
+	if req.Description != "" {
+		body.Description = &req.Description
+	}
+
+	resp, err := c.v1.AccountAllocateSubdomain(ctx, params, body, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[SubdomainResponse](resp)
+}
+
+func (c *Client) ListAccountSubdomains(ctx context.Context) (*AccountSubdomainsResponse, error) {
+	resp, err := c.v1.AccountListSubdomains(ctx, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[AccountSubdomainsResponse](resp)
+}
+
+func (c *Client) RevokeAccountSubdomains(ctx context.Context, ids []uuid.UUID) (*AccountRevokeResponse, error) {
+	resp, err := c.v1.AccountRevokeSubdomains(idempotent(ctx), internal.AccountRevokeRequest{
+		Ids: ids,
+	}, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[AccountRevokeResponse](resp)
+}
@@ -2,54 +2,82 @@ package dsdm
 
 import (
 	"context"
-	"crypto"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
-	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/lego"
-	llog "github.com/go-acme/lego/v4/log"
-	"github.com/go-acme/lego/v4/registration"
 	"github.com/google/uuid"
+	"github.com/miekg/dns"
 )
 
 var (
 	ErrAccountCreationError = errors.New("account creation failed")
 	ErrUnsupportedProvider  = errors.New("unsupported provider")
+	ErrMissingDirectory     = errors.New("custom provider requires a directory url")
+	ErrMissingEAB           = errors.New("provider requires external account binding credentials")
+	ErrMissingCAAIdentifier = errors.New("pinning caa requires a ca identifier")
+	ErrInvalidName          = errors.New("name is not within the subdomain")
+	ErrTooManyNames         = errors.New("too many names")
 )
 
-type acmeUser struct {
-	Email        string
-	Registration *registration.Resource
-	key          crypto.PrivateKey
-}
-
-func (u *acmeUser) GetEmail() string {
-	return u.Email
-}
-
-func (u *acmeUser) GetRegistration() *registration.Resource {
-	return u.Registration
-}
-
-func (u *acmeUser) GetPrivateKey() crypto.PrivateKey {
-	return u.key
-}
+// maxChallengeValues is the number of challenge values the server accepts.
+const maxChallengeValues = 10
 
 type AcquireCertificateRequest struct {
-	ID         uuid.UUID
-	Domain     string
-	Token      string
-	Provider   string
-	KeyType    certcrypto.KeyType
-	Timeout    time.Duration
+	ID     uuid.UUID
+	Domain string
+	Token  string
+	// Names to include in the certificate, each either Domain itself or a
+	// name below it such as "*.<domain>" or "api.<domain>". Defaults to the
+	// wildcard of Domain.
+	Names    []string
+	Provider string
+	KeyType  certcrypto.KeyType
+	// Timeout bounds the whole request when set.
+	Timeout time.Duration
+	// SilenceLog drops lego's own output while the request runs, unless
+	// Logger is set.
 	SilenceLog bool
+	// Logger receives progress of the request, including the requests made
+	// to the ACME server and lego's own output. As lego only logs through
+	// github.com/go-acme/lego/v4/log, that output is captured from there
+	// while the request runs, so requests setting Logger or SilenceLog run
+	// one at a time.
+	Logger Logger
+	// PinCAA restricts issuance for the subdomain by the provider to the
+	// account once registration succeeds. CAA records of other issuers and
+	// iodef records, read from the nameservers used for Propagation, are kept.
+	PinCAA bool
+	// Account is reused for issuance instead of registering a new one, in
+	// which case Provider, Email, EAB and Directory are ignored.
+	Account *ACMEAccount
+	// Email is the account contact. Providers that require one are given an
+	// address unlikely to exist when empty.
+	Email string
+	// EAB credentials are required by Google Trust Services and optional for
+	// custom providers. ZeroSSL credentials are generated when not given.
+	EAB *EABCredentials
+	// Directory is the ACME directory URL of ProviderCustom.
+	Directory string
+	// CAAIdentifier overrides the issuer domain used by PinCAA, and must be
+	// set to pin with ProviderCustom.
+	CAAIdentifier string
+	// RootCAs verifies the ACME server instead of the system roots.
+	RootCAs *x509.CertPool
+	// Propagation configures the check that challenge records are served by
+	// the authoritative nameservers before validation is requested.
+	Propagation PropagationOptions
+}
+
+type EABCredentials struct {
+	KeyID string
+	// HMACKey is the base64url encoded MAC key.
+	HMACKey string
 }
 
 type CertificateResponse struct {
@@ -66,63 +94,63 @@ func (c *Client) AcquireCertificate(
 	ctx context.Context,
 	request AcquireCertificateRequest,
 ) (*CertificateResponse, error) {
-	if request.SilenceLog {
-		llog.Logger = &nullLogger{}
-	}
+	if request.Timeout > 0 {
+		var cancel context.CancelFunc
 
-	ctx, cancel := context.WithTimeout(ctx, request.Timeout)
-	defer cancel()
-
-	emailID1, err := uuid.NewRandom()
-	if err != nil {
-		return nil, err
+		ctx, cancel = context.WithTimeout(ctx, request.Timeout)
+		defer cancel()
 	}
 
-	emailID2, err := uuid.NewRandom()
+	domains, err := certificateNames(request.Domain, request.Names)
 	if err != nil {
 		return nil, err
 	}
 
-	// Email is unlikely to exist
-	email := fmt.Sprintf("%s@%s.com", emailID1, emailID2)
+	providerName, directory := request.Provider, request.Directory
 
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if request.Account != nil {
+		providerName, directory = request.Account.Provider, request.Account.Directory
+	}
+
+	provider, err := resolveProvider(providerName, directory)
 	if err != nil {
 		return nil, err
 	}
 
-	user := &acmeUser{
-		Email: email,
-		key:   privateKey,
+	if request.CAAIdentifier != "" {
+		provider.caaIdentifier = request.CAAIdentifier
 	}
 
-	config := lego.NewConfig(user)
-	config.Certificate.KeyType = request.KeyType
-
-	registerOpts := registration.RegisterEABOptions{
-		TermsOfServiceAgreed: true,
-		Kid:                  "",
-		HmacEncoded:          "",
+	if request.PinCAA && provider.caaIdentifier == "" {
+		return nil, ErrMissingCAAIdentifier
 	}
 
-	switch request.Provider {
-	case ProviderZeroSSL:
-		config.CADirURL = zeroSSLURL
-
-		account, err := generateZeroSslAccount(ctx, email, request.Timeout)
+	account := request.Account
+
+	if account == nil {
+		account, err = c.NewACMEAccount(ctx, ACMEAccountRequest{
+			Provider:   request.Provider,
+			Directory:  request.Directory,
+			Email:      request.Email,
+			EAB:        request.EAB,
+			RootCAs:    request.RootCAs,
+			Timeout:    request.Timeout,
+			Logger:     request.Logger,
+			SilenceLog: request.SilenceLog,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("%w: %w", ErrAccountCreationError, err)
+			return nil, err
 		}
+	} else if account.Registration == nil {
+		return nil, fmt.Errorf("%w: missing registration", ErrInvalidACMEAccount)
+	}
 
-		if !account.Success {
-			return nil, fmt.Errorf("%w: unknown failure", ErrAccountCreationError)
-		}
+	defer legoLogs.route(request.Logger, request.SilenceLog)()
 
-		registerOpts.Kid = account.EABKID
-		registerOpts.HmacEncoded = account.EABHMACKey
-	default:
-		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProvider, request.Provider)
-	}
+	logger := loggerOrNop(request.Logger)
+
+	config := c.newLegoConfig(ctx, account, request.RootCAs, request.Logger)
+	config.Certificate.KeyType = request.KeyType
 
 	client, err := lego.NewClient(config)
 	if err != nil {
@@ -130,29 +158,53 @@ func (c *Client) AcquireCertificate(
 	}
 
 	dnsChallenge := c.NewDNSChallengeProvider(ctx, request.ID, request.Token)
+	dnsChallenge.Propagation = request.Propagation
+	dnsChallenge.Logger = request.Logger
 
-	// Disable propagation check, does not work currently
-	err = client.Challenge.SetDNS01Provider(dnsChallenge, dns01.DisableCompletePropagationRequirement())
+	err = client.Challenge.SetDNS01Provider(dnsChallenge, dnsChallenge.PropagationCheck())
 	if err != nil {
 		return nil, err
 	}
 
-	reg, err := client.Registration.RegisterWithExternalAccountBinding(registerOpts)
-	if err != nil {
-		return nil, err
+	if request.PinCAA {
+		// The records are replaced as a whole, so those already served are
+		// read back to keep other issuers and iodef contacts
+		existing, err := lookupCAA(ctx, request.Domain, request.Propagation.Nameservers)
+		if err != nil {
+			return nil, fmt.Errorf("read caa records: %w", err)
+		}
+
+		value := fmt.Sprintf("%s; accounturi=%s; validationmethods=dns-01", provider.caaIdentifier, account.Registration.URI)
+
+		if err := c.SetSubdomainCAA(ctx, SubdomainCAARequest{
+			ID:      request.ID,
+			Token:   request.Token,
+			Records: mergePinnedCAA(existing, provider.caaIdentifier, value),
+		}); err != nil {
+			return nil, err
+		}
 	}
 
-	user.Registration = reg
+	logger.Info("Requesting certificate", "domains", domains)
 
 	response, err := client.Certificate.Obtain(certificate.ObtainRequest{
-		Domains:                        []string{fmt.Sprintf("*.%s", request.Domain)},
+		Domains:                        domains,
 		Bundle:                         true,
 		AlwaysDeactivateAuthorizations: true,
 	})
 	if err != nil {
+		// lego does not observe the context, so report why its requests failed
+		if ctx.Err() != nil {
+			err = fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+
+		logger.Error("Certificate request failed", "domains", domains, "err", err)
+
 		return nil, err
 	}
 
+	logger.Info("Certificate obtained", "domain", response.Domain, "url", response.CertURL)
+
 	return &CertificateResponse{
 		Domain:            response.Domain,
 		CertURL:           response.CertURL,
@@ -164,22 +216,72 @@ func (c *Client) AcquireCertificate(
 	}, nil
 }
 
-type nullLogger struct{}
+func certificateNames(domain string, names []string) ([]string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
 
-func (n nullLogger) Fatal(_ ...interface{}) {
-}
+	if len(names) == 0 {
+		return []string{"*." + domain}, nil
+	}
 
-func (n nullLogger) Fatalln(_ ...interface{}) {
-}
+	if len(names) > maxChallengeValues {
+		return nil, fmt.Errorf("%w: at most %d are supported", ErrTooManyNames, maxChallengeValues)
+	}
 
-func (n nullLogger) Fatalf(_ string, _ ...interface{}) {
-}
+	res := make([]string, 0, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+		if name != domain && !strings.HasSuffix(name, "."+domain) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidName, name)
+		}
 
-func (n nullLogger) Print(_ ...interface{}) {
+		res = append(res, name)
+	}
+
+	return res, nil
 }
 
-func (n nullLogger) Println(_ ...interface{}) {
+// unlikelyEmail returns an address for providers that insist on a contact.
+func unlikelyEmail() (string, error) {
+	emailID1, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
+	emailID2, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s@%s.com", emailID1, emailID2), nil
 }
 
-func (n nullLogger) Printf(_ string, _ ...interface{}) {
+// mergePinnedCAA replaces the issue and issuewild records of issuer with value,
+// keeping the records of other issuers and iodef contacts.
+func mergePinnedCAA(existing []*dns.CAA, issuer string, value string) []CAARecord {
+	records := make([]CAARecord, 0, len(existing)+2)
+
+	for _, rr := range existing {
+		tag := CAATag(strings.ToLower(rr.Tag))
+
+		switch tag {
+		case CAAIssue, CAAIssueWild:
+			name, _, _ := strings.Cut(rr.Value, ";")
+			if strings.EqualFold(strings.TrimSpace(name), issuer) {
+				continue
+			}
+		case CAAIodef:
+		default:
+			// Tags the API does not accept cannot have been set through it
+			continue
+		}
+
+		records = append(records, CAARecord{Flag: int(rr.Flag), Tag: tag, Value: rr.Value})
+	}
+
+	return append(records,
+		CAARecord{Tag: CAAIssue, Value: value},
+		CAARecord{Tag: CAAIssueWild, Value: value},
+	)
 }
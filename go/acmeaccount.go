@@ -0,0 +1,362 @@
+package dsdm
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/registration"
+	"golang.org/x/crypto/acme"
+)
+
+var ErrInvalidACMEAccount = errors.New("invalid acme account")
+
+// ACMEAccount is a registration with an ACME provider. Accounts should be
+// created once, persisted using json.Marshal and passed to every
+// AcquireCertificate call, as new registrations count towards CA rate limits
+// and certificates can only be revoked by the account that requested them.
+type ACMEAccount struct {
+	Provider     string
+	Directory    string
+	Email        string
+	Registration *registration.Resource
+	key          crypto.Signer
+}
+
+type ACMEAccountRequest struct {
+	Provider string
+	// Directory is the ACME directory URL of ProviderCustom.
+	Directory string
+	// Email is the account contact. Providers that require one are given an
+	// address unlikely to exist when empty.
+	Email string
+	// EAB credentials are required by Google Trust Services and optional for
+	// custom providers. ZeroSSL credentials are generated when not given.
+	EAB *EABCredentials
+	// RootCAs verifies the ACME server instead of the system roots.
+	RootCAs *x509.CertPool
+	Timeout time.Duration
+	// Logger and SilenceLog apply as they do to AcquireCertificateRequest.
+	Logger     Logger
+	SilenceLog bool
+}
+
+type acmeAccountJSON struct {
+	Provider     string                 `json:"provider"`
+	Directory    string                 `json:"directory"`
+	Email        string                 `json:"email,omitempty"`
+	Registration *registration.Resource `json:"registration"`
+	Key          string                 `json:"key"`
+}
+
+func (a *ACMEAccount) GetEmail() string {
+	return a.Email
+}
+
+func (a *ACMEAccount) GetRegistration() *registration.Resource {
+	return a.Registration
+}
+
+func (a *ACMEAccount) GetPrivateKey() crypto.PrivateKey {
+	return a.key
+}
+
+// MarshalJSON encodes the account including its private key, which must be
+// stored securely.
+func (a ACMEAccount) MarshalJSON() ([]byte, error) {
+	if a.key == nil {
+		return nil, fmt.Errorf("%w: missing key", ErrInvalidACMEAccount)
+	}
+
+	return json.Marshal(acmeAccountJSON{
+		Provider:     a.Provider,
+		Directory:    a.Directory,
+		Email:        a.Email,
+		Registration: a.Registration,
+		Key:          string(certcrypto.PEMEncode(a.key)),
+	})
+}
+
+func (a *ACMEAccount) UnmarshalJSON(data []byte) error {
+	var decoded acmeAccountJSON
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if decoded.Registration == nil || decoded.Registration.URI == "" || decoded.Directory == "" {
+		return fmt.Errorf("%w: missing registration", ErrInvalidACMEAccount)
+	}
+
+	key, err := certcrypto.ParsePEMPrivateKey([]byte(decoded.Key))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidACMEAccount, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("%w: unsupported key", ErrInvalidACMEAccount)
+	}
+
+	*a = ACMEAccount{
+		Provider:     decoded.Provider,
+		Directory:    decoded.Directory,
+		Email:        decoded.Email,
+		Registration: decoded.Registration,
+		key:          signer,
+	}
+
+	return nil
+}
+
+// NewACMEAccount generates an account key and registers it with the provider,
+// agreeing to its terms of service.
+func (c *Client) NewACMEAccount(ctx context.Context, request ACMEAccountRequest) (*ACMEAccount, error) {
+	defer legoLogs.route(request.Logger, request.SilenceLog)()
+
+	provider, err := resolveProvider(request.Provider, request.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider.requiresEAB && request.EAB == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingEAB, request.Provider)
+	}
+
+	email := request.Email
+
+	if email == "" && provider.requiresEmail {
+		email, err = unlikelyEmail()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	registerOpts := registration.RegisterEABOptions{
+		TermsOfServiceAgreed: true,
+		Kid:                  "",
+		HmacEncoded:          "",
+	}
+
+	if request.EAB != nil {
+		registerOpts.Kid = request.EAB.KeyID
+		registerOpts.HmacEncoded = request.EAB.HMACKey
+	} else if request.Provider == ProviderZeroSSL {
+		account, err := c.generateZeroSslAccount(ctx, email, request.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrAccountCreationError, err)
+		}
+
+		if !account.Success {
+			return nil, fmt.Errorf("%w: unknown failure", ErrAccountCreationError)
+		}
+
+		registerOpts.Kid = account.EABKID
+		registerOpts.HmacEncoded = account.EABHMACKey
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := loggerOrNop(request.Logger)
+	logger.Info("Registering ACME account", "provider", request.Provider, "directory", provider.directory)
+
+	account := &ACMEAccount{
+		Provider:  request.Provider,
+		Directory: provider.directory,
+		Email:     email,
+		key:       privateKey,
+	}
+
+	client, err := lego.NewClient(c.newLegoConfig(ctx, account, request.RootCAs, request.Logger))
+	if err != nil {
+		return nil, err
+	}
+
+	var reg *registration.Resource
+
+	if registerOpts.Kid != "" {
+		reg, err = client.Registration.RegisterWithExternalAccountBinding(registerOpts)
+	} else {
+		reg, err = client.Registration.Register(registration.RegisterOptions{
+			TermsOfServiceAgreed: true,
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	account.Registration = reg
+
+	logger.Info("Registered ACME account", "uri", reg.URI)
+
+	return account, nil
+}
+
+// UpdateACMEAccountEmail replaces the contact of the account. An empty email
+// removes it, which some providers do not allow.
+func (c *Client) UpdateACMEAccountEmail(
+	ctx context.Context,
+	account *ACMEAccount,
+	email string,
+	rootCAs *x509.CertPool,
+) error {
+	updated := *account
+	updated.Email = email
+
+	defer legoLogs.route(nil, false)()
+
+	client, err := lego.NewClient(c.newLegoConfig(ctx, &updated, rootCAs, nil))
+	if err != nil {
+		return err
+	}
+
+	reg, err := client.Registration.UpdateRegistration(registration.RegisterOptions{
+		TermsOfServiceAgreed: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	account.Email = email
+	account.Registration = reg
+
+	return nil
+}
+
+// RolloverACMEAccountKey replaces the account key with a newly generated one.
+// The account must be persisted again afterwards, as the old key is no longer
+// accepted by the provider.
+func (c *Client) RolloverACMEAccountKey(ctx context.Context, account *ACMEAccount, rootCAs *x509.CertPool) error {
+	if account.Registration == nil {
+		return fmt.Errorf("%w: missing registration", ErrInvalidACMEAccount)
+	}
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	client := &acme.Client{
+		Key:          account.key,
+		DirectoryURL: account.Directory,
+		KID:          acme.KeyID(account.Registration.URI),
+		HTTPClient:   c.newLegoConfig(ctx, account, rootCAs, nil).HTTPClient,
+	}
+
+	if err := client.AccountKeyRollover(ctx, newKey); err != nil {
+		return err
+	}
+
+	account.key = newKey
+
+	return nil
+}
+
+// RevokeCertificate revokes a certificate previously acquired with the account.
+func (c *Client) RevokeCertificate(
+	ctx context.Context,
+	account *ACMEAccount,
+	cert []byte,
+	rootCAs *x509.CertPool,
+) error {
+	defer legoLogs.route(nil, false)()
+
+	client, err := lego.NewClient(c.newLegoConfig(ctx, account, rootCAs, nil))
+	if err != nil {
+		return err
+	}
+
+	return client.Certificate.Revoke(cert)
+}
+
+func resolveProvider(name string, directory string) (acmeProvider, error) {
+	if name == ProviderCustom {
+		if directory == "" {
+			return acmeProvider{}, ErrMissingDirectory
+		}
+
+		return acmeProvider{
+			directory: directory,
+		}, nil
+	}
+
+	provider, ok := acmeProviders[name]
+	if !ok {
+		return acmeProvider{}, fmt.Errorf("%w: %s", ErrUnsupportedProvider, name)
+	}
+
+	return provider, nil
+}
+
+// newLegoConfig returns a lego configuration for the account, whose requests
+// are bound to ctx and made through the HTTP client of c.
+func (c *Client) newLegoConfig(
+	ctx context.Context,
+	account *ACMEAccount,
+	rootCAs *x509.CertPool,
+	logger Logger,
+) *lego.Config {
+	config := lego.NewConfig(account)
+	config.CADirURL = account.Directory
+
+	base := config.HTTPClient.Transport
+	timeout := config.HTTPClient.Timeout
+
+	// A client given with WithHTTPClient carries proxy and CA settings that
+	// apply to the CA as much as to the API
+	if c.httpClient != http.DefaultClient {
+		base = c.httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+
+		if c.httpClient.Timeout > 0 {
+			timeout = c.httpClient.Timeout
+		}
+	}
+
+	if rootCAs != nil {
+		transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+
+		if t, ok := base.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+
+		tlsConfig.RootCAs = rootCAs
+		transport.TLSClientConfig = tlsConfig
+
+		base = transport
+	}
+
+	config.HTTPClient = &http.Client{
+		Timeout: timeout,
+		Transport: &acmeTransport{
+			ctx:    ctx,
+			logger: loggerOrNop(logger),
+			base:   base,
+		},
+	}
+
+	return config
+}
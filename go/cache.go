@@ -0,0 +1,146 @@
+package dsdm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+const subdomainCacheKey = "subdomain"
+
+// ErrCacheMiss is returned by a Cache when no data is stored under a key.
+var ErrCacheMiss = errors.New("cache miss")
+
+// Cache persists the certificates and ACME accounts of a Manager, so they
+// survive restarts. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// Locker is implemented by caches that can be shared, so that only one user
+// at a time requests a subdomain or obtains a certificate stored under a key.
+type Locker interface {
+	// Lock blocks until the lock for key is held or the context is done, and
+	// returns a function releasing it.
+	Lock(ctx context.Context, key string) (func(), error)
+}
+
+// lockCache locks key if the cache supports it.
+func lockCache(ctx context.Context, cache Cache, key string) (func(), error) {
+	locker, ok := cache.(Locker)
+	if !ok {
+		return func() {}, nil
+	}
+
+	return locker.Lock(ctx, key)
+}
+
+// CachedSubdomain returns the subdomain stored in the cache, requesting and
+// storing a new one when there is none. Processes sharing a cache therefore
+// share a single subdomain.
+func (c *Client) CachedSubdomain(ctx context.Context, cache Cache) (*SubdomainResponse, error) {
+	unlock, err := lockCache(ctx, cache, subdomainCacheKey)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	data, err := cache.Get(ctx, subdomainCacheKey)
+
+	switch {
+	case errors.Is(err, ErrCacheMiss):
+	case err != nil:
+		return nil, err
+	default:
+		var sub SubdomainResponse
+
+		if err := json.Unmarshal(data, &sub); err != nil {
+			return nil, err
+		}
+
+		return &sub, nil
+	}
+
+	sub, err := c.RequestSubdomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = json.Marshal(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cache.Put(ctx, subdomainCacheKey, data); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// MemCache is a Cache held in memory, for tests and short-lived processes.
+type MemCache struct {
+	mu    sync.Mutex
+	data  map[string][]byte
+	locks map[string]chan struct{}
+}
+
+func NewMemCache() *MemCache {
+	return &MemCache{
+		data:  make(map[string][]byte),
+		locks: make(map[string]chan struct{}),
+	}
+}
+
+func (c *MemCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.data[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+func (c *MemCache) Put(_ context.Context, key string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data[key] = append([]byte(nil), data...)
+
+	return nil
+}
+
+func (c *MemCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.data, key)
+
+	return nil
+}
+
+func (c *MemCache) Lock(ctx context.Context, key string) (func(), error) {
+	c.mu.Lock()
+
+	lock, ok := c.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		c.locks[key] = lock
+	}
+
+	c.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,132 @@
+package dsdm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const lockPollInterval = 100 * time.Millisecond
+
+var ErrInvalidCacheKey = errors.New("invalid cache key")
+
+// DirCache is a Cache storing each key as a file in a directory. Writes are
+// atomic and Lock uses OS file locks, so the directory can be shared by
+// multiple processes on one host.
+type DirCache string
+
+func (d DirCache) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+
+	return data, err
+}
+
+func (d DirCache) Put(_ context.Context, key string, data []byte) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(string(d), 0o700); err != nil {
+		return err
+	}
+
+	// Written to a temporary file first, so readers never see partial data
+	tmp, err := os.CreateTemp(string(d), key+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (d DirCache) Delete(_ context.Context, key string) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (d DirCache) Lock(ctx context.Context, key string) (func(), error) {
+	path, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(string(d), 0o700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+
+			return nil, err
+		}
+
+		if locked {
+			return func() {
+				_ = unlockFile(f)
+				f.Close()
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			f.Close()
+
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+func (d DirCache) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCacheKey, key)
+	}
+
+	return filepath.Join(string(d), key), nil
+}
@@ -0,0 +1,87 @@
+package dsdm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/csnewman/dyndirect/go/internal"
+)
+
+const (
+	FeatureShortIDs      = "short-ids"
+	FeatureVanityLabels  = "vanity-labels"
+	FeatureDelegation    = "delegation"
+	FeatureAddressPolicy = "address-policy"
+	FeatureAbuseReport   = "abuse-report"
+	FeatureServiceHints  = "service-hints"
+	FeatureCAA           = "caa"
+	FeatureWebhooks      = "webhooks"
+	FeatureAccounts      = "accounts"
+	FeatureTokenRotation = "token-rotation"
+	FeatureDoH           = "doh"
+	FeatureDoT           = "dot"
+
+	apiVersion = "v1"
+)
+
+var ErrUnsupportedVersion = errors.New("unsupported api version")
+
+type DiscoveryDocument = internal.DiscoveryResponse
+
+type Discovery struct {
+	Document DiscoveryDocument
+	APIURL   string
+}
+
+func Discover(ctx context.Context, baseURL string, opts ...Option) (*Discovery, error) {
+	c, err := New(baseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.v1.GetDiscovery(ctx, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := parseResponse[DiscoveryDocument](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range doc.Versions {
+		if v.Version == apiVersion {
+			return &Discovery{
+				Document: *doc,
+				APIURL:   v.Url,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: server does not support %s", ErrUnsupportedVersion, apiVersion)
+}
+
+func (d *Discovery) Supports(feature string) bool {
+	for _, f := range d.Document.Features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *Discovery) SupportsScheme(scheme SubdomainScheme) bool {
+	for _, s := range d.Document.IdSchemes {
+		if s == scheme {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *Discovery) NewClient(opts ...Option) (*Client, error) {
+	return New(d.APIURL, opts...)
+}
@@ -2,17 +2,50 @@ package dsdm
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/google/uuid"
+	"github.com/miekg/dns"
 )
 
+const (
+	defaultPropagationTimeout  = 2 * time.Minute
+	defaultPropagationInterval = 2 * time.Second
+	propagationQueryTimeout    = 5 * time.Second
+)
+
+var ErrNoNameservers = errors.New("no authoritative nameservers found")
+
+// PropagationOptions configures how challenge records are verified on the
+// authoritative nameservers before the CA is asked to validate them.
+type PropagationOptions struct {
+	// Nameservers overrides the discovered authoritative nameservers, as
+	// host:port pairs.
+	Nameservers []string
+	// Timeout defaults to two minutes.
+	Timeout time.Duration
+	// Interval between checks defaults to two seconds.
+	Interval time.Duration
+}
+
 type DNSChallengeProvider struct {
 	//nolint:containedctx
 	ctx    context.Context
 	client *Client
 	id     uuid.UUID
 	token  string
+
+	Propagation PropagationOptions
+	Logger      Logger
+
+	mu     sync.Mutex
+	values []string
 }
 
 func (c *Client) NewDNSChallengeProvider(
@@ -28,18 +61,253 @@ func (c *Client) NewDNSChallengeProvider(
 	}
 }
 
+// Present publishes the challenge value alongside those of other pending
+// names, as the apex and wildcard names share a challenge record.
 func (p *DNSChallengeProvider) Present(domain string, _ string, keyAuth string) error {
 	info := dns01.GetChallengeInfo(domain, keyAuth)
 
+	loggerOrNop(p.Logger).Info("Presenting ACME challenge", "fqdn", info.EffectiveFQDN)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values := make([]string, 0, len(p.values)+1)
+	values = append(values, p.values...)
+	values = append(values, info.Value)
+
+	if err := p.setValues(values); err != nil {
+		return err
+	}
+
+	p.values = values
+
+	return nil
+}
+
+func (p *DNSChallengeProvider) CleanUp(domain string, _ string, keyAuth string) error {
+	info := dns01.GetChallengeInfo(domain, keyAuth)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values := make([]string, 0, len(p.values))
+	removed := false
+
+	for _, v := range p.values {
+		if v == info.Value && !removed {
+			removed = true
+
+			continue
+		}
+
+		values = append(values, v)
+	}
+
+	if !removed {
+		return nil
+	}
+
+	if err := p.setValues(values); err != nil {
+		return err
+	}
+
+	p.values = values
+
+	return nil
+}
+
+func (p *DNSChallengeProvider) setValues(values []string) error {
 	return p.client.SetSubdomainACMEChallenge(p.ctx, SubdomainACMEChallengeRequest{
-		ID:    p.id,
-		Token: p.token,
-		Values: []string{
-			info.Value,
-		},
+		ID:     p.id,
+		Token:  p.token,
+		Values: values,
 	})
 }
 
-func (p *DNSChallengeProvider) CleanUp(_ string, _ string, _ string) error {
-	return nil
+// Timeout implements challenge.ProviderTimeout, controlling how long the
+// propagation check is retried.
+func (p *DNSChallengeProvider) Timeout() (time.Duration, time.Duration) {
+	timeout := p.Propagation.Timeout
+	if timeout == 0 {
+		timeout = defaultPropagationTimeout
+	}
+
+	interval := p.Propagation.Interval
+	if interval == 0 {
+		interval = defaultPropagationInterval
+	}
+
+	return timeout, interval
+}
+
+// PropagationCheck returns the lego option verifying challenge records on the
+// authoritative nameservers of the DSDM server, replacing the default check
+// which relies on recursive resolvers.
+func (p *DNSChallengeProvider) PropagationCheck() dns01.ChallengeOption {
+	return dns01.WrapPreCheck(func(domain, fqdn, value string, _ dns01.PreCheckFunc) (bool, error) {
+		return p.checkPropagation(domain, fqdn, value)
+	})
+}
+
+func (p *DNSChallengeProvider) checkPropagation(domain string, fqdn string, value string) (bool, error) {
+	// Ends the wait, lego then fails on its next request to the ACME server
+	if err := p.ctx.Err(); err != nil {
+		return true, err
+	}
+
+	nameservers := p.Propagation.Nameservers
+
+	if len(nameservers) == 0 {
+		var err error
+
+		nameservers, err = authoritativeNameservers(p.ctx, domain)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	client := &dns.Client{
+		Timeout: propagationQueryTimeout,
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(fqdn), dns.TypeTXT)
+	msg.RecursionDesired = false
+
+	var lastErr error
+
+	answered := 0
+
+	// Unreachable addresses are skipped, as hosts often lack IPv6 connectivity
+	for _, ns := range nameservers {
+		res, _, err := client.ExchangeContext(p.ctx, msg, ns)
+		if err != nil {
+			lastErr = fmt.Errorf("query %s: %w", ns, err)
+
+			continue
+		}
+
+		if res.Rcode != dns.RcodeSuccess || !containsTXT(res, value) {
+			loggerOrNop(p.Logger).Debug("Waiting for ACME challenge propagation", "fqdn", fqdn, "nameserver", ns)
+
+			return false, nil
+		}
+
+		answered++
+	}
+
+	return answered > 0, lastErr
+}
+
+// authoritativeNameservers finds the closest zone above domain with NS records
+// and returns the addresses of its nameservers.
+func authoritativeNameservers(ctx context.Context, domain string) ([]string, error) {
+	zone := strings.TrimSuffix(domain, ".")
+
+	for {
+		dot := strings.IndexByte(zone, '.')
+		if dot < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoNameservers, domain)
+		}
+
+		zone = zone[dot+1:]
+
+		records, err := net.DefaultResolver.LookupNS(ctx, zone)
+		if err != nil || len(records) == 0 {
+			continue
+		}
+
+		var (
+			nameservers []string
+			lookupErr   error
+		)
+
+		// A nameserver that does not resolve is skipped, as the others can
+		// still confirm propagation
+		for _, record := range records {
+			addrs, err := net.DefaultResolver.LookupHost(ctx, record.Host)
+			if err != nil {
+				lookupErr = err
+
+				continue
+			}
+
+			for _, addr := range addrs {
+				nameservers = append(nameservers, net.JoinHostPort(addr, "53"))
+			}
+		}
+
+		if len(nameservers) == 0 && lookupErr != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrNoNameservers, zone, lookupErr)
+		} else if len(nameservers) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoNameservers, zone)
+		}
+
+		return nameservers, nil
+	}
+}
+
+// lookupCAA returns the CAA records served for domain by the first of the
+// nameservers that answers.
+func lookupCAA(ctx context.Context, domain string, nameservers []string) ([]*dns.CAA, error) {
+	if len(nameservers) == 0 {
+		var err error
+
+		nameservers, err = authoritativeNameservers(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client := &dns.Client{
+		Timeout: propagationQueryTimeout,
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), dns.TypeCAA)
+	msg.RecursionDesired = false
+
+	var lastErr error
+
+	for _, ns := range nameservers {
+		res, _, err := client.ExchangeContext(ctx, msg, ns)
+		if err != nil {
+			lastErr = fmt.Errorf("query %s: %w", ns, err)
+
+			continue
+		}
+
+		if res.Rcode != dns.RcodeSuccess {
+			lastErr = fmt.Errorf("query %s: %s", ns, dns.RcodeToString[res.Rcode])
+
+			continue
+		}
+
+		var records []*dns.CAA
+
+		for _, rr := range res.Answer {
+			if caa, ok := rr.(*dns.CAA); ok {
+				records = append(records, caa)
+			}
+		}
+
+		return records, nil
+	}
+
+	return nil, lastErr
+}
+
+func containsTXT(res *dns.Msg, value string) bool {
+	for _, rr := range res.Answer {
+		txt, ok := rr.(*dns.TXT)
+		if !ok {
+			continue
+		}
+
+		if strings.Join(txt.Txt, "") == value {
+			return true
+		}
+	}
+
+	return false
 }
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/csnewman/dyndirect/go/internal"
 	"github.com/google/uuid"
@@ -19,27 +20,146 @@ type OverviewResponse = internal.OverviewResponse
 
 type SubdomainResponse = internal.NewSubdomainResponse
 
+type SubdomainScheme = internal.SubdomainScheme
+
+const (
+	SchemeUUID  SubdomainScheme = internal.Uuid
+	SchemeShort SubdomainScheme = internal.Short
+)
+
+type SubdomainLabelRequest struct {
+	ID    uuid.UUID
+	Token string
+	Label string
+}
+
+type SubdomainLabelResponse = internal.SubdomainLabelResponse
+
+type SubdomainTokenResponse = internal.SubdomainTokenResponse
+
+type AddressClass = internal.AddressClass
+
+const (
+	AddressLoopback  AddressClass = internal.Loopback
+	AddressPrivate   AddressClass = internal.Private
+	AddressLinkLocal AddressClass = internal.LinkLocal
+	AddressULA       AddressClass = internal.Ula
+	AddressPublic    AddressClass = internal.Public
+)
+
+type SubdomainAddressPolicyRequest struct {
+	ID    uuid.UUID
+	Token string
+	// Classes that may be synthesized. A nil slice restores the server
+	// default, while an empty slice blocks every class.
+	Classes []AddressClass
+}
+
+type AddressPolicyResponse = internal.AddressPolicyResponse
+
+type ALPNProtocol = internal.ALPNProtocol
+
+const (
+	ALPNHTTP1 ALPNProtocol = internal.Http11
+	ALPNHTTP2 ALPNProtocol = internal.H2
+	ALPNHTTP3 ALPNProtocol = internal.H3
+)
+
+type SubdomainServiceRequest struct {
+	ID    uuid.UUID
+	Token string
+	Port  uint16
+	ALPN  []ALPNProtocol
+}
+
+type CAARecord = internal.CAARecord
+
+type CAATag = internal.CAATag
+
+const (
+	CAAIssue     CAATag = internal.Issue
+	CAAIssueWild CAATag = internal.Issuewild
+	CAAIodef     CAATag = internal.Iodef
+)
+
+type SubdomainCAARequest struct {
+	ID      uuid.UUID
+	Token   string
+	Records []CAARecord
+}
+
+type AbuseReportRequest struct {
+	Domain  string
+	Reason  string
+	Contact string
+}
+
+type AbuseReportResponse = internal.AbuseReportResponse
+
+type DelegationGlue = internal.DelegationGlue
+
+type DelegationDS = internal.DelegationDS
+
+type SubdomainDelegationRequest struct {
+	ID          uuid.UUID
+	Token       string
+	Nameservers []string
+	Glue        []DelegationGlue
+	DS          []DelegationDS
+}
+
 type SubdomainACMEChallengeRequest struct {
 	ID     uuid.UUID
 	Token  string
 	Values []string
+	TTL    time.Duration
 }
 
+type ACMEChallengeResponse = internal.AcmeChallengeResponse
+
 type Client struct {
-	server string
-	v1     *internal.Client
+	server     string
+	v1         *internal.Client
+	accountKey string
+	retry      *RetryPolicy
+	httpClient *http.Client
+	userAgent  string
+	headers    http.Header
+	timeout    time.Duration
 }
 
-func New(server string) (*Client, error) {
-	c, err := internal.NewClient(server)
+func New(server string, opts ...Option) (*Client, error) {
+	client := &Client{
+		server:     server,
+		httpClient: http.DefaultClient,
+		userAgent:  clientUserAgent,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.httpClient == nil {
+		client.httpClient = http.DefaultClient
+	}
+
+	var doer internal.HttpRequestDoer = httpClientWithTimeout(client.httpClient, client.timeout)
+
+	if client.retry != nil {
+		doer = &retryDoer{
+			doer:   doer,
+			policy: *client.retry,
+		}
+	}
+
+	c, err := internal.NewClient(server, internal.WithHTTPClient(doer))
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		server: server,
-		v1:     c,
-	}, nil
+	client.v1 = c
+
+	return client, nil
 }
 
 func (c *Client) GetOverview(ctx context.Context) (*OverviewResponse, error) {
@@ -52,7 +172,18 @@ func (c *Client) GetOverview(ctx context.Context) (*OverviewResponse, error) {
 }
 
 func (c *Client) RequestSubdomain(ctx context.Context) (*SubdomainResponse, error) {
-	resp, err := c.v1.GenerateSubdomain(ctx, c.requestHook)
+	resp, err := c.v1.GenerateSubdomain(ctx, &internal.GenerateSubdomainParams{}, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[SubdomainResponse](resp)
+}
+
+func (c *Client) RequestSubdomainWithScheme(ctx context.Context, scheme SubdomainScheme) (*SubdomainResponse, error) {
+	resp, err := c.v1.GenerateSubdomain(ctx, &internal.GenerateSubdomainParams{
+		Scheme: &scheme,
+	}, c.requestHook)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +191,31 @@ func (c *Client) RequestSubdomain(ctx context.Context) (*SubdomainResponse, erro
 	return parseResponse[SubdomainResponse](resp)
 }
 
+func (c *Client) ClaimSubdomainLabel(ctx context.Context, req SubdomainLabelRequest) (*SubdomainLabelResponse, error) {
+	resp, err := c.v1.SubdomainClaimLabel(ctx, req.ID, internal.SubdomainLabelRequest{
+		Token: req.Token,
+		Label: req.Label,
+	}, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[SubdomainLabelResponse](resp)
+}
+
+// RotateSubdomainToken replaces the control token of a subdomain. The previous
+// token stops working, so the returned token must be persisted.
+func (c *Client) RotateSubdomainToken(ctx context.Context, id uuid.UUID, token string) (*SubdomainTokenResponse, error) {
+	resp, err := c.v1.SubdomainRotateToken(ctx, id, internal.SubdomainTokenRequest{
+		Token: token,
+	}, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[SubdomainTokenResponse](resp)
+}
+
 func GetDomainForIP(rootDomain string, ip net.IP) string {
 	rootDomain = strings.ToLower(rootDomain)
 
@@ -74,10 +230,54 @@ func (c *Client) SetSubdomainACMEChallenge(
 	ctx context.Context,
 	req SubdomainACMEChallengeRequest,
 ) error {
-	resp, err := c.v1.SubdomainAcmeChallenge(ctx, req.ID, internal.SubdomainAcmeChallengeRequest{
+	_, err := c.SetSubdomainACMEChallengeWithExpiry(ctx, req)
+
+	return err
+}
+
+// SetSubdomainACMEChallengeWithExpiry sets the challenge values, returning
+// when the server stops serving them.
+func (c *Client) SetSubdomainACMEChallengeWithExpiry(
+	ctx context.Context,
+	req SubdomainACMEChallengeRequest,
+) (*ACMEChallengeResponse, error) {
+	body := internal.SubdomainAcmeChallengeRequest{
 		Token:  req.Token,
 		Values: req.Values,
-	}, c.requestHook)
+	}
+
+	if req.TTL > 0 {
+		ttl := int(req.TTL / time.Second)
+		body.Ttl = &ttl
+	}
+
+	resp, err := c.v1.SubdomainAcmeChallenge(idempotent(ctx), req.ID, body, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[ACMEChallengeResponse](resp)
+}
+
+func (c *Client) SetSubdomainDelegation(ctx context.Context, req SubdomainDelegationRequest) error {
+	body := internal.SubdomainDelegationRequest{
+		Token:       req.Token,
+		Nameservers: req.Nameservers,
+	}
+
+	if body.Nameservers == nil {
+		body.Nameservers = []string{}
+	}
+
+	if len(req.Glue) > 0 {
+		body.Glue = &req.Glue
+	}
+
+	if len(req.DS) > 0 {
+		body.Ds = &req.DS
+	}
+
+	resp, err := c.v1.SubdomainDelegation(idempotent(ctx), req.ID, body, c.requestHook)
 	if err != nil {
 		return err
 	}
@@ -85,20 +285,112 @@ func (c *Client) SetSubdomainACMEChallenge(
 	return parseEmptyResponse(resp)
 }
 
-func (c *Client) requestHook(_ context.Context, req *http.Request) error {
-	req.Header.Set("User-Agent", "dsdm-go-client/1.0")
+func (c *Client) ClearSubdomainDelegation(ctx context.Context, id uuid.UUID, token string) error {
+	return c.SetSubdomainDelegation(ctx, SubdomainDelegationRequest{
+		ID:    id,
+		Token: token,
+	})
+}
 
-	return nil
+func (c *Client) SetSubdomainAddressPolicy(
+	ctx context.Context,
+	req SubdomainAddressPolicyRequest,
+) (*AddressPolicyResponse, error) {
+	body := internal.SubdomainAddressPolicyRequest{
+		Token: req.Token,
+	}
+
+	if req.Classes != nil {
+		body.Classes = &req.Classes
+	}
+
+	resp, err := c.v1.SubdomainAddressPolicy(idempotent(ctx), req.ID, body, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[AddressPolicyResponse](resp)
 }
 
-type APIError struct {
-	Status    int
-	ErrorCode string
-	Message   string
+func (c *Client) SetSubdomainService(ctx context.Context, req SubdomainServiceRequest) error {
+	body := internal.SubdomainServiceRequest{
+		Token: req.Token,
+	}
+
+	if req.Port != 0 {
+		port := int(req.Port)
+		body.Port = &port
+	}
+
+	if len(req.ALPN) > 0 {
+		body.Alpn = &req.ALPN
+	}
+
+	resp, err := c.v1.SubdomainService(idempotent(ctx), req.ID, body, c.requestHook)
+	if err != nil {
+		return err
+	}
+
+	return parseEmptyResponse(resp)
 }
 
-func (e APIError) Error() string {
-	return fmt.Sprintf("dsdm: api error: %d %s '%s'", e.Status, e.ErrorCode, e.Message)
+func (c *Client) ClearSubdomainService(ctx context.Context, id uuid.UUID, token string) error {
+	return c.SetSubdomainService(ctx, SubdomainServiceRequest{
+		ID:    id,
+		Token: token,
+	})
+}
+
+func (c *Client) SetSubdomainCAA(ctx context.Context, req SubdomainCAARequest) error {
+	records := req.Records
+	if records == nil {
+		records = []CAARecord{}
+	}
+
+	resp, err := c.v1.SubdomainCaa(idempotent(ctx), req.ID, internal.SubdomainCAARequest{
+		Token:   req.Token,
+		Records: records,
+	}, c.requestHook)
+	if err != nil {
+		return err
+	}
+
+	return parseEmptyResponse(resp)
+}
+
+func (c *Client) ClearSubdomainCAA(ctx context.Context, id uuid.UUID, token string) error {
+	return c.SetSubdomainCAA(ctx, SubdomainCAARequest{
+		ID:    id,
+		Token: token,
+	})
+}
+
+func (c *Client) ReportAbuse(ctx context.Context, req AbuseReportRequest) (*AbuseReportResponse, error) {
+	body := internal.AbuseReportRequest{
+		Domain: req.Domain,
+		Reason: req.Reason,
+	}
+
+	if req.Contact != "" {
+		body.Contact = &req.Contact
+	}
+
+	resp, err := c.v1.ReportAbuse(ctx, body, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[AbuseReportResponse](resp)
+}
+
+func (c *Client) requestHook(_ context.Context, req *http.Request) error {
+	c.applyHeaders(req)
+
+	if c.accountKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accountKey)
+	}
+
+	return nil
 }
 
 func parseResponse[T any](rsp *http.Response) (*T, error) {
@@ -110,6 +402,10 @@ func parseResponse[T any](rsp *http.Response) (*T, error) {
 		return nil, err
 	}
 
+	if rsp.StatusCode != http.StatusOK {
+		return nil, newAPIError(rsp, bodyBytes)
+	}
+
 	ct := rsp.Header.Get("Content-Type")
 	if !strings.Contains(ct, "json") {
 		return nil, APIError{
@@ -119,25 +415,12 @@ func parseResponse[T any](rsp *http.Response) (*T, error) {
 		}
 	}
 
-	if rsp.StatusCode == http.StatusOK {
-		var dest T
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-
-		return &dest, nil
-	}
-
-	var dest internal.ErrorResponse
+	var dest T
 	if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 		return nil, err
 	}
 
-	return nil, APIError{
-		Status:    rsp.StatusCode,
-		ErrorCode: dest.Error,
-		Message:   dest.Message,
-	}
+	return &dest, nil
 }
 
 func parseEmptyResponse(rsp *http.Response) error {
@@ -153,23 +436,5 @@ func parseEmptyResponse(rsp *http.Response) error {
 		return nil
 	}
 
-	ct := rsp.Header.Get("Content-Type")
-	if !strings.Contains(ct, "json") {
-		return APIError{
-			Status:    rsp.StatusCode,
-			ErrorCode: "invalid-response",
-			Message:   fmt.Sprintf("Unexpected content-type %s", ct),
-		}
-	}
-
-	var dest internal.ErrorResponse
-	if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-		return err
-	}
-
-	return APIError{
-		Status:    rsp.StatusCode,
-		ErrorCode: dest.Error,
-		Message:   dest.Message,
-	}
+	return newAPIError(rsp, bodyBytes)
 }
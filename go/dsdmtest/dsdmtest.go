@@ -0,0 +1,314 @@
+// Package dsdmtest runs an in-process DSDM server, so code built on
+// dsdm.Client can be tested without network access.
+package dsdmtest
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dsdm "github.com/csnewman/dyndirect/go"
+	"github.com/csnewman/dyndirect/server"
+	"github.com/google/uuid"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultRootDomain = "dsdm.test"
+
+	listenAttempts = 10
+)
+
+// Server is a DSDM server with an in-memory store, serving the API over TLS
+// and DNS over UDP and TCP on loopback ports.
+type Server struct {
+	// URL is the base URL of the API.
+	URL string
+	// DNSAddr is the host:port address of the DNS server.
+	DNSAddr string
+	// RootDomain is the domain subdomains are allocated under.
+	RootDomain string
+	// Store holds the state of the server, for inspection beyond the helpers.
+	Store *server.MemStore
+
+	tb     testing.TB
+	http   *httptest.Server
+	dns    []*dns.Server
+	mu     sync.Mutex
+	faults []dsdm.APIError
+}
+
+// NewServer starts a server with default settings, which is closed when the
+// test completes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	return NewServerWithConfig(tb, server.Config{})
+}
+
+// NewServerWithConfig starts a server with cfg. Listen addresses, the API
+// host and the store are overridden, and RootDomain and TokenKey default to
+// test values when unset.
+func NewServerWithConfig(tb testing.TB, cfg server.Config) *Server {
+	tb.Helper()
+
+	if cfg.RootDomain == "" {
+		cfg.RootDomain = DefaultRootDomain
+	}
+
+	// The server matches queries against the fully qualified root
+	cfg.RootDomain = dns.Fqdn(strings.ToLower(cfg.RootDomain))
+
+	if cfg.TokenKey == "" {
+		cfg.TokenKey = "dsdmtest"
+	}
+
+	cfg.Store = "mem"
+	cfg.ACMEEnabled = false
+	cfg.APIListenHTTP = ""
+	cfg.APIListenHTTPS = ""
+	cfg.DoTListen = ""
+	cfg.AdminListen = ""
+	cfg.StatsFile = filepath.Join(tb.TempDir(), "stats.json")
+
+	if err := cfg.Validate(); err != nil {
+		tb.Fatalf("dsdmtest: %v", err)
+	}
+
+	hs := httptest.NewUnstartedServer(nil)
+
+	// The discovery document points at the API host
+	cfg.APIHost = hs.Listener.Addr().String()
+
+	logger := zap.NewNop().Sugar()
+
+	store, err := server.NewMemStore(logger, cfg)
+	if err != nil {
+		tb.Fatalf("dsdmtest: create store: %v", err)
+	}
+
+	srv := server.New(logger, cfg, store)
+
+	handler, err := srv.HTTPHandler()
+	if err != nil {
+		tb.Fatalf("dsdmtest: build handler: %v", err)
+	}
+
+	s := &Server{
+		RootDomain: strings.TrimSuffix(cfg.RootDomain, "."),
+		Store:      store,
+		tb:         tb,
+		http:       hs,
+	}
+
+	hs.Config.Handler = s.injectFaults(handler)
+	hs.StartTLS()
+
+	s.URL = hs.URL + "/"
+
+	tb.Cleanup(s.Close)
+
+	if err := s.startDNS(srv); err != nil {
+		tb.Fatalf("dsdmtest: start dns: %v", err)
+	}
+
+	return s
+}
+
+// startDNS listens for UDP and TCP on the same port, retrying when the port
+// chosen for UDP is taken for TCP.
+func (s *Server) startDNS(handler dns.Handler) error {
+	var lastErr error
+
+	for i := 0; i < listenAttempts; i++ {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			return err
+		}
+
+		l, err := net.Listen("tcp", pc.LocalAddr().String())
+		if err != nil {
+			pc.Close()
+
+			lastErr = err
+
+			continue
+		}
+
+		s.DNSAddr = pc.LocalAddr().String()
+
+		for _, ds := range []*dns.Server{
+			{PacketConn: pc, Handler: handler},
+			{Listener: l, Handler: handler},
+		} {
+			started := make(chan struct{})
+			ds.NotifyStartedFunc = func() { close(started) }
+
+			s.mu.Lock()
+			s.dns = append(s.dns, ds)
+			s.mu.Unlock()
+
+			go func(ds *dns.Server) {
+				_ = ds.ActivateAndServe()
+			}(ds)
+
+			<-started
+		}
+
+		return nil
+	}
+
+	return lastErr
+}
+
+// Close stops the server. It is called when the test completes.
+func (s *Server) Close() {
+	s.http.Close()
+
+	s.mu.Lock()
+	servers := s.dns
+	s.dns = nil
+	s.mu.Unlock()
+
+	for _, ds := range servers {
+		_ = ds.Shutdown()
+	}
+}
+
+// HTTPClient returns an HTTP client trusting the certificate of the server.
+func (s *Server) HTTPClient() *http.Client {
+	return s.http.Client()
+}
+
+// Client returns a client for the server. Options are applied after the
+// HTTP client of the server, so it can be replaced.
+func (s *Server) Client(opts ...dsdm.Option) *dsdm.Client {
+	s.tb.Helper()
+
+	c, err := dsdm.New(s.URL, append([]dsdm.Option{dsdm.WithHTTPClient(s.HTTPClient())}, opts...)...)
+	if err != nil {
+		s.tb.Fatalf("dsdmtest: create client: %v", err)
+	}
+
+	return c
+}
+
+// Resolver returns a resolver sending all queries to the server.
+func (s *Server) Resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			var d net.Dialer
+
+			return d.DialContext(ctx, network, s.DNSAddr)
+		},
+	}
+}
+
+// Propagation returns options checking challenge records on the server, for
+// use with AcquireCertificateRequest.
+func (s *Server) Propagation() dsdm.PropagationOptions {
+	return dsdm.PropagationOptions{
+		Nameservers: []string{s.DNSAddr},
+	}
+}
+
+// ChallengeValues returns the ACME challenge values set for a subdomain.
+func (s *Server) ChallengeValues(id uuid.UUID) []string {
+	s.tb.Helper()
+
+	values, _, err := s.Store.GetACMEChallengeTokens(context.Background(), id)
+	if err != nil {
+		s.tb.Fatalf("dsdmtest: get challenge values: %v", err)
+	}
+
+	return values
+}
+
+// SetChallengeValues replaces the ACME challenge values of a subdomain, as if
+// set through the API.
+func (s *Server) SetChallengeValues(id uuid.UUID, values []string) {
+	s.tb.Helper()
+
+	if err := s.Store.SetACMEChallengeTokens(context.Background(), id, values, time.Hour); err != nil {
+		s.tb.Fatalf("dsdmtest: set challenge values: %v", err)
+	}
+}
+
+// InjectError fails the next API request with err. Errors are queued, so
+// consecutive calls fail consecutive requests. A RetryAfter is sent as the
+// Retry-After header.
+func (s *Server) InjectError(err dsdm.APIError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err.Status == 0 {
+		err.Status = http.StatusInternalServerError
+	}
+
+	if err.ErrorCode == "" {
+		err.ErrorCode = "internal-error"
+	}
+
+	s.faults = append(s.faults, err)
+}
+
+// RateLimit rejects the next n API requests with a too-many-requests error.
+func (s *Server) RateLimit(n int, retryAfter time.Duration) {
+	for i := 0; i < n; i++ {
+		s.InjectError(dsdm.APIError{
+			Status:     http.StatusTooManyRequests,
+			ErrorCode:  "too-many-requests",
+			Message:    "Too many requests",
+			RetryAfter: retryAfter,
+		})
+	}
+}
+
+func (s *Server) nextFault() (dsdm.APIError, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.faults) == 0 {
+		return dsdm.APIError{}, false
+	}
+
+	fault := s.faults[0]
+	s.faults = s.faults[1:]
+
+	return fault, true
+}
+
+func (s *Server) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault, ok := s.nextFault()
+		if !ok {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if fault.RetryAfter > 0 {
+			// Rounded up, as the header only carries whole seconds
+			secs := (fault.RetryAfter + time.Second - 1) / time.Second
+			w.Header().Set("Retry-After", strconv.Itoa(int(secs)))
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(fault.Status)
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"error":   fault.ErrorCode,
+			"message": fault.Message,
+		})
+	})
+}
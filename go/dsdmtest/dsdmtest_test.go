@@ -0,0 +1,89 @@
+package dsdmtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	dsdm "github.com/csnewman/dyndirect/go"
+	"github.com/csnewman/dyndirect/go/dsdmtest"
+)
+
+func TestChallengeResolves(t *testing.T) {
+	ctx := context.Background()
+	srv := dsdmtest.NewServer(t)
+	c := srv.Client()
+
+	sub, err := c.RequestSubdomain(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.SetSubdomainACMEChallenge(ctx, dsdm.SubdomainACMEChallengeRequest{
+		ID:     sub.Id,
+		Token:  sub.Token,
+		Values: []string{"challenge"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := srv.Resolver().LookupTXT(ctx, "_acme-challenge."+sub.Domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(values) != 1 || values[0] != "challenge" {
+		t.Fatalf("unexpected TXT records %v", values)
+	}
+}
+
+func TestInjectError(t *testing.T) {
+	ctx := context.Background()
+	srv := dsdmtest.NewServer(t)
+	c := srv.Client()
+
+	srv.InjectError(dsdm.APIError{
+		Status:    http.StatusNotFound,
+		ErrorCode: "not-found",
+		Message:   "Unknown subdomain",
+	})
+
+	if _, err := c.GetOverview(ctx); !errors.Is(err, dsdm.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := c.GetOverview(ctx); err != nil {
+		t.Fatalf("expected only one request to fail, got %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+	srv := dsdmtest.NewServer(t)
+
+	srv.RateLimit(1, 30*time.Second)
+
+	_, err := srv.Client().RequestSubdomain(ctx)
+	if !errors.Is(err, dsdm.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	if after, ok := dsdm.RetryAfter(err); !ok || after != 30*time.Second {
+		t.Fatalf("expected a 30s retry after, got %v", after)
+	}
+
+	srv.RateLimit(2, 0)
+
+	c := srv.Client(dsdm.WithRetryPolicy(dsdm.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	}))
+
+	if _, err := c.RequestSubdomain(ctx); err != nil {
+		t.Fatalf("expected the rate limit to be retried, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package dsdm
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedCacheVersion = 1
+	encryptedCacheSaltLen = 16
+	encryptedCacheHeader  = 1 + encryptedCacheSaltLen + chacha20poly1305.NonceSizeX
+
+	// scrypt parameters recommended for interactive use
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var ErrCacheDecrypt = errors.New("cache entry could not be decrypted")
+
+// EncryptedCache wraps a Cache, encrypting entries with XChaCha20-Poly1305
+// under a key derived from a passphrase using scrypt. Entries are bound to
+// their key, so they cannot be swapped by someone with access to the
+// underlying cache.
+type EncryptedCache struct {
+	cache      Cache
+	passphrase []byte
+
+	mu   sync.Mutex
+	salt []byte
+	keys map[string][]byte
+}
+
+func NewEncryptedCache(cache Cache, passphrase string) *EncryptedCache {
+	return &EncryptedCache{
+		cache:      cache,
+		passphrase: []byte(passphrase),
+		keys:       make(map[string][]byte),
+	}
+}
+
+func (c *EncryptedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < encryptedCacheHeader+chacha20poly1305.Overhead || data[0] != encryptedCacheVersion {
+		return nil, ErrCacheDecrypt
+	}
+
+	aead, err := c.aead(data[1 : 1+encryptedCacheSaltLen])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := data[1+encryptedCacheSaltLen : encryptedCacheHeader]
+
+	plain, err := aead.Open(nil, nonce, data[encryptedCacheHeader:], []byte(key))
+	if err != nil {
+		return nil, ErrCacheDecrypt
+	}
+
+	return plain, nil
+}
+
+func (c *EncryptedCache) Put(ctx context.Context, key string, data []byte) error {
+	salt, err := c.writeSalt()
+	if err != nil {
+		return err
+	}
+
+	aead, err := c.aead(salt)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, encryptedCacheHeader)
+	header[0] = encryptedCacheVersion
+	copy(header[1:], salt)
+
+	nonce := header[1+encryptedCacheSaltLen:]
+
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	return c.cache.Put(ctx, key, aead.Seal(header, nonce, data, []byte(key)))
+}
+
+func (c *EncryptedCache) Delete(ctx context.Context, key string) error {
+	return c.cache.Delete(ctx, key)
+}
+
+func (c *EncryptedCache) Lock(ctx context.Context, key string) (func(), error) {
+	return lockCache(ctx, c.cache, key)
+}
+
+// writeSalt returns the salt used for new entries. It is generated once per
+// instance, so the expensive key derivation is not repeated on every write.
+func (c *EncryptedCache) writeSalt() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.salt == nil {
+		salt := make([]byte, encryptedCacheSaltLen)
+
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+
+		c.salt = salt
+	}
+
+	return c.salt, nil
+}
+
+func (c *EncryptedCache) aead(salt []byte) (cipher.AEAD, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := c.keys[string(salt)]
+	if !ok {
+		var err error
+
+		key, err = scrypt.Key(c.passphrase, salt, scryptN, scryptR, scryptP, chacha20poly1305.KeySize)
+		if err != nil {
+			return nil, err
+		}
+
+		c.keys[string(salt)] = key
+	}
+
+	return chacha20poly1305.NewX(key)
+}
@@ -0,0 +1,98 @@
+package dsdm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csnewman/dyndirect/go/internal"
+)
+
+// Sentinel errors matched by APIError using errors.Is.
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrNotFound         = errors.New("not found")
+	ErrRateLimited      = errors.New("rate limited")
+	ErrSubdomainBlocked = errors.New("subdomain blocked")
+	ErrSubdomainRevoked = errors.New("subdomain revoked")
+)
+
+type APIError struct {
+	Status    int
+	ErrorCode string
+	Message   string
+	// RetryAfter is the delay requested by the server for rate limited and
+	// unavailable responses, or zero.
+	RetryAfter time.Duration
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("dsdm: api error: %d %s '%s'", e.Status, e.ErrorCode, e.Message)
+}
+
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidToken:
+		return e.Status == http.StatusUnauthorized || e.ErrorCode == "invalid-token" ||
+			e.ErrorCode == "invalid-account-key"
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrSubdomainBlocked:
+		return e.ErrorCode == "subdomain-blocked"
+	case ErrSubdomainRevoked:
+		return e.ErrorCode == "subdomain-revoked"
+	default:
+		return false
+	}
+}
+
+// RetryAfter returns the delay requested by the server if err is a rate
+// limited or unavailable API error.
+func RetryAfter(err error) (time.Duration, bool) {
+	var apiErr APIError
+
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter == 0 {
+		return 0, false
+	}
+
+	return apiErr.RetryAfter, true
+}
+
+// newAPIError describes a failed response. Retry-After is read before the
+// body, so it is kept for responses from proxies that are not JSON.
+func newAPIError(rsp *http.Response, body []byte) APIError {
+	err := APIError{
+		Status: rsp.StatusCode,
+	}
+
+	if after, ok := parseRetryAfter(rsp.Header.Get("Retry-After")); ok {
+		err.RetryAfter = after
+	}
+
+	ct := rsp.Header.Get("Content-Type")
+	if !strings.Contains(ct, "json") {
+		err.ErrorCode = "invalid-response"
+		err.Message = fmt.Sprintf("Unexpected content-type %s", ct)
+
+		return err
+	}
+
+	var decoded internal.ErrorResponse
+
+	if jsonErr := json.Unmarshal(body, &decoded); jsonErr != nil {
+		err.ErrorCode = "invalid-response"
+		err.Message = fmt.Sprintf("Invalid error response: %v", jsonErr)
+
+		return err
+	}
+
+	err.ErrorCode = decoded.Error
+	err.Message = decoded.Message
+
+	return err
+}
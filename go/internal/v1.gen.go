@@ -12,11 +12,271 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
 )
 
+const (
+	AccountKeyScopes = "AccountKey.Scopes"
+)
+
+// Defines values for ALPNProtocol.
+const (
+	H2     ALPNProtocol = "h2"
+	H3     ALPNProtocol = "h3"
+	Http11 ALPNProtocol = "http/1.1"
+)
+
+// Defines values for AddressClass.
+const (
+	LinkLocal AddressClass = "link-local"
+	Loopback  AddressClass = "loopback"
+	Private   AddressClass = "private"
+	Public    AddressClass = "public"
+	Ula       AddressClass = "ula"
+)
+
+// Defines values for CAATag.
+const (
+	Iodef     CAATag = "iodef"
+	Issue     CAATag = "issue"
+	Issuewild CAATag = "issuewild"
+)
+
+// Defines values for SubdomainScheme.
+const (
+	Short SubdomainScheme = "short"
+	Uuid  SubdomainScheme = "uuid"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Delivered WebhookDeliveryStatus = "delivered"
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WebhookEvent.
+const (
+	AcmeSet            WebhookEvent = "acme_set"
+	AddressPolicySet   WebhookEvent = "address_policy_set"
+	CaaSet             WebhookEvent = "caa_set"
+	DelegationSet      WebhookEvent = "delegation_set"
+	LabelClaimed       WebhookEvent = "label_claimed"
+	ServiceSet         WebhookEvent = "service_set"
+	SubdomainBlocked   WebhookEvent = "subdomain_blocked"
+	SubdomainRevoked   WebhookEvent = "subdomain_revoked"
+	SubdomainUnblocked WebhookEvent = "subdomain_unblocked"
+	TokenRotated       WebhookEvent = "token_rotated"
+	WebhookSet         WebhookEvent = "webhook_set"
+)
+
+// ALPNProtocol Application protocol.
+type ALPNProtocol string
+
+// AbuseReportRequest Abuse Report Request.
+type AbuseReportRequest struct {
+	// Contact Optional contact details of the reporter.
+	Contact *string `json:"contact,omitempty"`
+
+	// Domain Reported domain.
+	Domain string `json:"domain"`
+
+	// Reason Description of the abuse.
+	Reason string `json:"reason"`
+}
+
+// AbuseReportResponse Abuse Report Response.
+type AbuseReportResponse struct {
+	// Id Report ID.
+	Id openapi_types.UUID `json:"id"`
+}
+
+// AccountResponse Account Response.
+type AccountResponse struct {
+	// Id Account ID.
+	Id openapi_types.UUID `json:"id"`
+
+	// Key Account API key.
+	Key string `json:"key"`
+}
+
+// AccountRevokeRequest Account Revoke Request.
+type AccountRevokeRequest struct {
+	// Ids IDs of the subdomains to revoke.
+	Ids []openapi_types.UUID `json:"ids"`
+}
+
+// AccountRevokeResponse Account Revoke Response.
+type AccountRevokeResponse struct {
+	// Revoked IDs of the subdomains that were revoked.
+	Revoked []openapi_types.UUID `json:"revoked"`
+}
+
+// AccountSubdomain Subdomain owned by an account.
+type AccountSubdomain struct {
+	// Created Time the subdomain was allocated.
+	Created time.Time `json:"created"`
+
+	// Description Description given on allocation.
+	Description *string `json:"description,omitempty"`
+
+	// Domain Allocated domain.
+	Domain string `json:"domain"`
+
+	// Id Subdomain ID.
+	Id openapi_types.UUID `json:"id"`
+
+	// Revoked Time the subdomain was revoked.
+	Revoked *time.Time `json:"revoked,omitempty"`
+}
+
+// AccountSubdomainRequest Account Subdomain Request.
+type AccountSubdomainRequest struct {
+	// Description Optional description of the subdomain.
+	Description *string `json:"description,omitempty"`
+}
+
+// AccountSubdomainsResponse Account Subdomains Response.
+type AccountSubdomainsResponse struct {
+	Subdomains []AccountSubdomain `json:"subdomains"`
+}
+
+// AcmeChallengeResponse ACME Challenge Response.
+type AcmeChallengeResponse struct {
+	// Expires Time the tokens will stop being served.
+	Expires time.Time `json:"expires"`
+
+	// Ttl Effective lifetime of the tokens in seconds.
+	Ttl int `json:"ttl"`
+}
+
+// AddressClass Class of IP address.
+type AddressClass string
+
+// AddressPolicyResponse Address Policy Response.
+type AddressPolicyResponse struct {
+	// Classes Effective address classes that will be synthesized.
+	Classes []AddressClass `json:"classes"`
+}
+
+// CAARecord CAA record.
+type CAARecord struct {
+	// Flag Flags, 128 marks the record as critical.
+	Flag int `json:"flag"`
+
+	// Tag CAA property tag.
+	Tag CAATag `json:"tag"`
+
+	// Value Issuer domain with optional parameters, or an iodef URL.
+	Value string `json:"value"`
+}
+
+// CAATag CAA property tag.
+type CAATag string
+
+// CreateAccountRequest Create Account Request.
+type CreateAccountRequest struct {
+	// Name Optional name of the account.
+	Name *string `json:"name,omitempty"`
+}
+
+// DelegationDS Delegation signer record.
+type DelegationDS struct {
+	Algorithm int `json:"algorithm"`
+
+	// Digest Hex encoded digest.
+	Digest     string `json:"digest"`
+	DigestType int    `json:"digest_type"`
+	KeyTag     int    `json:"key_tag"`
+}
+
+// DelegationGlue Nameserver glue address.
+type DelegationGlue struct {
+	// Ip IPv4 or IPv6 address.
+	Ip string `json:"ip"`
+
+	// Name Nameserver name.
+	Name string `json:"name"`
+}
+
+// DiscoveryLimits Request limits.
+type DiscoveryLimits struct {
+	// AccountSubdomains Maximum subdomains per account, including revoked subdomains.
+	AccountSubdomains int `json:"account_subdomains"`
+
+	// AcmeValues Maximum ACME challenge values per subdomain.
+	AcmeValues int `json:"acme_values"`
+
+	// ChallengeTtl Maximum ACME challenge lifetime in seconds.
+	ChallengeTtl int `json:"challenge_ttl"`
+
+	// LabelMaxLength Maximum vanity label length.
+	LabelMaxLength int `json:"label_max_length"`
+
+	// LabelMinLength Minimum vanity label length.
+	LabelMinLength int `json:"label_min_length"`
+
+	// Nameservers Maximum delegated nameservers per subdomain.
+	Nameservers int `json:"nameservers"`
+}
+
+// DiscoveryRateLimit Rate limit.
+type DiscoveryRateLimit struct {
+	// Limit Number of requests allowed per period.
+	Limit int `json:"limit"`
+
+	// Period Period in seconds.
+	Period int `json:"period"`
+
+	// Scope Scope the limit applies to, such as an operation.
+	Scope string `json:"scope"`
+}
+
+// DiscoveryResponse Discovery Response.
+type DiscoveryResponse struct {
+	// AuthModes Supported authentication modes.
+	AuthModes []string `json:"auth_modes"`
+
+	// Features Optional features supported by the server.
+	Features []string `json:"features"`
+
+	// IdSchemes Supported subdomain ID schemes.
+	IdSchemes []SubdomainScheme `json:"id_schemes"`
+
+	// Limits Request limits.
+	Limits DiscoveryLimits `json:"limits"`
+
+	// RateLimits Rate limits applied by the server.
+	RateLimits []DiscoveryRateLimit `json:"rate_limits"`
+
+	// RecordTypes DNS record types served for subdomains.
+	RecordTypes []string `json:"record_types"`
+
+	// ServerVersion Server Version.
+	ServerVersion string `json:"server_version"`
+
+	// Versions Supported API versions.
+	Versions []DiscoveryVersion `json:"versions"`
+
+	// Zones Zones subdomains are allocated from.
+	Zones []string `json:"zones"`
+}
+
+// DiscoveryVersion API version.
+type DiscoveryVersion struct {
+	// Spec URL of the OpenAPI document.
+	Spec string `json:"spec"`
+
+	// Url Base URL of the API.
+	Url string `json:"url"`
+
+	// Version API version identifier.
+	Version string `json:"version"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -31,7 +291,7 @@ type NewSubdomainResponse struct {
 	// Id Subdomain ID.
 	Id openapi_types.UUID `json:"id"`
 
-	// Token Control Token.
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
 	Token string `json:"token"`
 }
 
@@ -46,16 +306,208 @@ type OverviewResponse struct {
 
 // SubdomainAcmeChallengeRequest Subdomain ACME Challenge Request.
 type SubdomainAcmeChallengeRequest struct {
-	// Token Control Token.
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
 	Token string `json:"token"`
 
+	// Ttl Requested lifetime of the tokens in seconds, capped by the server.
+	Ttl *int `json:"ttl,omitempty"`
+
 	// Values ACME Tokens.
 	Values []string `json:"values"`
 }
 
+// SubdomainAddressPolicyRequest Subdomain Address Policy Request.
+type SubdomainAddressPolicyRequest struct {
+	// Classes Address classes that may be synthesized. An empty list blocks all classes, while omitting the field restores the server default.
+	Classes *[]AddressClass `json:"classes,omitempty"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainCAARequest Subdomain CAA Request.
+type SubdomainCAARequest struct {
+	// Records CAA records.
+	Records []CAARecord `json:"records"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainDelegationRequest Subdomain Delegation Request.
+type SubdomainDelegationRequest struct {
+	// Ds Delegation signer records.
+	Ds *[]DelegationDS `json:"ds,omitempty"`
+
+	// Glue Glue addresses for nameservers within the delegated subdomain.
+	Glue *[]DelegationGlue `json:"glue,omitempty"`
+
+	// Nameservers Fully qualified nameserver names.
+	Nameservers []string `json:"nameservers"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainLabelRequest Subdomain Label Request.
+type SubdomainLabelRequest struct {
+	// Label Vanity label.
+	Label string `json:"label"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainLabelResponse Subdomain Label Response.
+type SubdomainLabelResponse struct {
+	// Domain Claimed domain.
+	Domain string `json:"domain"`
+}
+
+// SubdomainScheme Subdomain ID scheme.
+type SubdomainScheme string
+
+// SubdomainServiceRequest Subdomain Service Request.
+type SubdomainServiceRequest struct {
+	// Alpn Supported application protocols.
+	Alpn *[]ALPNProtocol `json:"alpn,omitempty"`
+
+	// Port Port the service listens on.
+	Port *int `json:"port,omitempty"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainTokenRequest Subdomain Token Request.
+type SubdomainTokenRequest struct {
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainTokenResponse Subdomain Token Response.
+type SubdomainTokenResponse struct {
+	// Token New Control Token.
+	Token string `json:"token"`
+}
+
+// SubdomainWebhookRequest Subdomain Webhook Request.
+type SubdomainWebhookRequest struct {
+	// Events Events to deliver. Defaults to all events.
+	Events *[]WebhookEvent `json:"events,omitempty"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+
+	// Url HTTPS URL events are posted to.
+	Url *string `json:"url,omitempty"`
+}
+
+// SubdomainWebhookStatusRequest Subdomain Webhook Status Request.
+type SubdomainWebhookStatusRequest struct {
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// WebhookDelivery Webhook delivery.
+type WebhookDelivery struct {
+	// Attempts Number of delivery attempts made.
+	Attempts int `json:"attempts"`
+
+	// Created Time the event occurred.
+	Created time.Time `json:"created"`
+
+	// Event Subdomain event.
+	Event WebhookEvent `json:"event"`
+
+	// Id Delivery ID, also sent in the X-DSDM-Delivery header.
+	Id openapi_types.UUID `json:"id"`
+
+	// LastError Error from the most recent failed attempt.
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttempt Time of the next attempt while pending.
+	NextAttempt *time.Time `json:"next_attempt,omitempty"`
+
+	// Status Webhook delivery status.
+	Status WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus Webhook delivery status.
+type WebhookDeliveryStatus string
+
+// WebhookEvent Subdomain event.
+type WebhookEvent string
+
+// WebhookResponse Webhook Response.
+type WebhookResponse struct {
+	// Secret Secret used to sign deliveries. Absent when the webhook was removed.
+	Secret *string `json:"secret,omitempty"`
+}
+
+// WebhookStatusResponse Webhook Status Response.
+type WebhookStatusResponse struct {
+	// Deliveries Most recent deliveries, newest first.
+	Deliveries []WebhookDelivery `json:"deliveries"`
+
+	// Events Events that are delivered.
+	Events []WebhookEvent `json:"events"`
+
+	// Url Registered URL. Absent when no webhook is registered.
+	Url *string `json:"url,omitempty"`
+}
+
+// AccountAllocateSubdomainParams defines parameters for AccountAllocateSubdomain.
+type AccountAllocateSubdomainParams struct {
+	// Scheme ID scheme used for the subdomain label. Defaults to the server configured scheme.
+	Scheme *SubdomainScheme `form:"scheme,omitempty" json:"scheme,omitempty"`
+}
+
+// GenerateSubdomainParams defines parameters for GenerateSubdomain.
+type GenerateSubdomainParams struct {
+	// Scheme ID scheme used for the subdomain label. Defaults to the server configured scheme.
+	Scheme *SubdomainScheme `form:"scheme,omitempty" json:"scheme,omitempty"`
+}
+
+// ReportAbuseJSONRequestBody defines body for ReportAbuse for application/json ContentType.
+type ReportAbuseJSONRequestBody = AbuseReportRequest
+
+// CreateAccountJSONRequestBody defines body for CreateAccount for application/json ContentType.
+type CreateAccountJSONRequestBody = CreateAccountRequest
+
+// AccountAllocateSubdomainJSONRequestBody defines body for AccountAllocateSubdomain for application/json ContentType.
+type AccountAllocateSubdomainJSONRequestBody = AccountSubdomainRequest
+
+// AccountRevokeSubdomainsJSONRequestBody defines body for AccountRevokeSubdomains for application/json ContentType.
+type AccountRevokeSubdomainsJSONRequestBody = AccountRevokeRequest
+
 // SubdomainAcmeChallengeJSONRequestBody defines body for SubdomainAcmeChallenge for application/json ContentType.
 type SubdomainAcmeChallengeJSONRequestBody = SubdomainAcmeChallengeRequest
 
+// SubdomainAddressPolicyJSONRequestBody defines body for SubdomainAddressPolicy for application/json ContentType.
+type SubdomainAddressPolicyJSONRequestBody = SubdomainAddressPolicyRequest
+
+// SubdomainCaaJSONRequestBody defines body for SubdomainCaa for application/json ContentType.
+type SubdomainCaaJSONRequestBody = SubdomainCAARequest
+
+// SubdomainDelegationJSONRequestBody defines body for SubdomainDelegation for application/json ContentType.
+type SubdomainDelegationJSONRequestBody = SubdomainDelegationRequest
+
+// SubdomainClaimLabelJSONRequestBody defines body for SubdomainClaimLabel for application/json ContentType.
+type SubdomainClaimLabelJSONRequestBody = SubdomainLabelRequest
+
+// SubdomainServiceJSONRequestBody defines body for SubdomainService for application/json ContentType.
+type SubdomainServiceJSONRequestBody = SubdomainServiceRequest
+
+// SubdomainRotateTokenJSONRequestBody defines body for SubdomainRotateToken for application/json ContentType.
+type SubdomainRotateTokenJSONRequestBody = SubdomainTokenRequest
+
+// SubdomainWebhookJSONRequestBody defines body for SubdomainWebhook for application/json ContentType.
+type SubdomainWebhookJSONRequestBody = SubdomainWebhookRequest
+
+// SubdomainWebhookStatusJSONRequestBody defines body for SubdomainWebhookStatus for application/json ContentType.
+type SubdomainWebhookStatusJSONRequestBody = SubdomainWebhookStatusRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -132,13 +584,79 @@ type ClientInterface interface {
 	// GetOverview request
 	GetOverview(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetDiscovery request
+	GetDiscovery(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReportAbuse request with any body
+	ReportAbuseWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReportAbuse(ctx context.Context, body ReportAbuseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAccount request with any body
+	CreateAccountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAccount(ctx context.Context, body CreateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AccountListSubdomains request
+	AccountListSubdomains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AccountAllocateSubdomain request with any body
+	AccountAllocateSubdomainWithBody(ctx context.Context, params *AccountAllocateSubdomainParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AccountAllocateSubdomain(ctx context.Context, params *AccountAllocateSubdomainParams, body AccountAllocateSubdomainJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AccountRevokeSubdomains request with any body
+	AccountRevokeSubdomainsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AccountRevokeSubdomains(ctx context.Context, body AccountRevokeSubdomainsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GenerateSubdomain request
-	GenerateSubdomain(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GenerateSubdomain(ctx context.Context, params *GenerateSubdomainParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SubdomainAcmeChallenge request with any body
 	SubdomainAcmeChallengeWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	SubdomainAcmeChallenge(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainAddressPolicy request with any body
+	SubdomainAddressPolicyWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainAddressPolicy(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAddressPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainCaa request with any body
+	SubdomainCaaWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainCaa(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainCaaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainDelegation request with any body
+	SubdomainDelegationWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainDelegation(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainClaimLabel request with any body
+	SubdomainClaimLabelWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainClaimLabel(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainClaimLabelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainService request with any body
+	SubdomainServiceWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainService(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainServiceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainRotateToken request with any body
+	SubdomainRotateTokenWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainRotateToken(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainRotateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainWebhook request with any body
+	SubdomainWebhookWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainWebhook(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubdomainWebhookStatus request with any body
+	SubdomainWebhookStatusWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubdomainWebhookStatus(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetOverview(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -153,8 +671,8 @@ func (c *Client) GetOverview(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) GenerateSubdomain(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGenerateSubdomainRequest(c.Server)
+func (c *Client) GetDiscovery(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDiscoveryRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -165,8 +683,8 @@ func (c *Client) GenerateSubdomain(ctx context.Context, reqEditors ...RequestEdi
 	return c.Client.Do(req)
 }
 
-func (c *Client) SubdomainAcmeChallengeWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSubdomainAcmeChallengeRequestWithBody(c.Server, subdomainId, contentType, body)
+func (c *Client) ReportAbuseWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportAbuseRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -177,8 +695,8 @@ func (c *Client) SubdomainAcmeChallengeWithBody(ctx context.Context, subdomainId
 	return c.Client.Do(req)
 }
 
-func (c *Client) SubdomainAcmeChallenge(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSubdomainAcmeChallengeRequest(c.Server, subdomainId, body)
+func (c *Client) ReportAbuse(ctx context.Context, body ReportAbuseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportAbuseRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -189,88 +707,328 @@ func (c *Client) SubdomainAcmeChallenge(ctx context.Context, subdomainId openapi
 	return c.Client.Do(req)
 }
 
-// NewGetOverviewRequest generates requests for GetOverview
-func NewGetOverviewRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) CreateAccountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAccountRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) CreateAccount(ctx context.Context, body CreateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAccountRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) AccountListSubdomains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAccountListSubdomainsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGenerateSubdomainRequest generates requests for GenerateSubdomain
-func NewGenerateSubdomainRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) AccountAllocateSubdomainWithBody(ctx context.Context, params *AccountAllocateSubdomainParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAccountAllocateSubdomainRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/subdomain")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) AccountAllocateSubdomain(ctx context.Context, params *AccountAllocateSubdomainParams, body AccountAllocateSubdomainJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAccountAllocateSubdomainRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+func (c *Client) AccountRevokeSubdomainsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAccountRevokeSubdomainsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewSubdomainAcmeChallengeRequest calls the generic SubdomainAcmeChallenge builder with application/json body
-func NewSubdomainAcmeChallengeRequest(server string, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) AccountRevokeSubdomains(ctx context.Context, body AccountRevokeSubdomainsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAccountRevokeSubdomainsRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewSubdomainAcmeChallengeRequestWithBody(server, subdomainId, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewSubdomainAcmeChallengeRequestWithBody generates requests for SubdomainAcmeChallenge with any type of body
-func NewSubdomainAcmeChallengeRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+func (c *Client) GenerateSubdomain(ctx context.Context, params *GenerateSubdomainParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGenerateSubdomainRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam0 string
+func (c *Client) SubdomainAcmeChallengeWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainAcmeChallengeRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+func (c *Client) SubdomainAcmeChallenge(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainAcmeChallengeRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainAddressPolicyWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainAddressPolicyRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainAddressPolicy(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAddressPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainAddressPolicyRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainCaaWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainCaaRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainCaa(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainCaaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainCaaRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainDelegationWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainDelegationRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainDelegation(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainDelegationRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainClaimLabelWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainClaimLabelRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainClaimLabel(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainClaimLabelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainClaimLabelRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainServiceWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainServiceRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainService(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainServiceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainServiceRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainRotateTokenWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainRotateTokenRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainRotateToken(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainRotateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainRotateTokenRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainWebhookWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainWebhookRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainWebhook(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainWebhookRequest(c.Server, subdomainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainWebhookStatusWithBody(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainWebhookStatusRequestWithBody(c.Server, subdomainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubdomainWebhookStatus(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubdomainWebhookStatusRequest(c.Server, subdomainId, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetOverviewRequest generates requests for GetOverview
+func NewGetOverviewRequest(server string) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/subdomain/%s/acme-challenge", pathParam0)
+	operationPath := fmt.Sprintf("/")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -280,221 +1038,2108 @@ func NewSubdomainAcmeChallengeRequestWithBody(server string, subdomainId openapi
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewGetDiscoveryRequest generates requests for GetDiscovery
+func NewGetDiscoveryRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/.well-known/dsdm")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewReportAbuseRequest calls the generic ReportAbuse builder with application/json body
+func NewReportAbuseRequest(server string, body ReportAbuseJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	bodyReader = bytes.NewReader(buf)
+	return NewReportAbuseRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewReportAbuseRequestWithBody generates requests for ReportAbuse with any type of body
+func NewReportAbuseRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/abuse-report")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateAccountRequest calls the generic CreateAccount builder with application/json body
+func NewCreateAccountRequest(server string, body CreateAccountJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAccountRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateAccountRequestWithBody generates requests for CreateAccount with any type of body
+func NewCreateAccountRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/account")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAccountListSubdomainsRequest generates requests for AccountListSubdomains
+func NewAccountListSubdomainsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/account/subdomains")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAccountAllocateSubdomainRequest calls the generic AccountAllocateSubdomain builder with application/json body
+func NewAccountAllocateSubdomainRequest(server string, params *AccountAllocateSubdomainParams, body AccountAllocateSubdomainJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAccountAllocateSubdomainRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewAccountAllocateSubdomainRequestWithBody generates requests for AccountAllocateSubdomain with any type of body
+func NewAccountAllocateSubdomainRequestWithBody(server string, params *AccountAllocateSubdomainParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/account/subdomains")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Scheme != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "scheme", runtime.ParamLocationQuery, *params.Scheme); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAccountRevokeSubdomainsRequest calls the generic AccountRevokeSubdomains builder with application/json body
+func NewAccountRevokeSubdomainsRequest(server string, body AccountRevokeSubdomainsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAccountRevokeSubdomainsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAccountRevokeSubdomainsRequestWithBody generates requests for AccountRevokeSubdomains with any type of body
+func NewAccountRevokeSubdomainsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/account/subdomains/revoke")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGenerateSubdomainRequest generates requests for GenerateSubdomain
+func NewGenerateSubdomainRequest(server string, params *GenerateSubdomainParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Scheme != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "scheme", runtime.ParamLocationQuery, *params.Scheme); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubdomainAcmeChallengeRequest calls the generic SubdomainAcmeChallenge builder with application/json body
+func NewSubdomainAcmeChallengeRequest(server string, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainAcmeChallengeRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainAcmeChallengeRequestWithBody generates requests for SubdomainAcmeChallenge with any type of body
+func NewSubdomainAcmeChallengeRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/acme-challenge", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainAddressPolicyRequest calls the generic SubdomainAddressPolicy builder with application/json body
+func NewSubdomainAddressPolicyRequest(server string, subdomainId openapi_types.UUID, body SubdomainAddressPolicyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainAddressPolicyRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainAddressPolicyRequestWithBody generates requests for SubdomainAddressPolicy with any type of body
+func NewSubdomainAddressPolicyRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/address-policy", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainCaaRequest calls the generic SubdomainCaa builder with application/json body
+func NewSubdomainCaaRequest(server string, subdomainId openapi_types.UUID, body SubdomainCaaJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainCaaRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainCaaRequestWithBody generates requests for SubdomainCaa with any type of body
+func NewSubdomainCaaRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/caa", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainDelegationRequest calls the generic SubdomainDelegation builder with application/json body
+func NewSubdomainDelegationRequest(server string, subdomainId openapi_types.UUID, body SubdomainDelegationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainDelegationRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainDelegationRequestWithBody generates requests for SubdomainDelegation with any type of body
+func NewSubdomainDelegationRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/delegation", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainClaimLabelRequest calls the generic SubdomainClaimLabel builder with application/json body
+func NewSubdomainClaimLabelRequest(server string, subdomainId openapi_types.UUID, body SubdomainClaimLabelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainClaimLabelRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainClaimLabelRequestWithBody generates requests for SubdomainClaimLabel with any type of body
+func NewSubdomainClaimLabelRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/label", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainServiceRequest calls the generic SubdomainService builder with application/json body
+func NewSubdomainServiceRequest(server string, subdomainId openapi_types.UUID, body SubdomainServiceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainServiceRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainServiceRequestWithBody generates requests for SubdomainService with any type of body
+func NewSubdomainServiceRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/service", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainRotateTokenRequest calls the generic SubdomainRotateToken builder with application/json body
+func NewSubdomainRotateTokenRequest(server string, subdomainId openapi_types.UUID, body SubdomainRotateTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainRotateTokenRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainRotateTokenRequestWithBody generates requests for SubdomainRotateToken with any type of body
+func NewSubdomainRotateTokenRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/token", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainWebhookRequest calls the generic SubdomainWebhook builder with application/json body
+func NewSubdomainWebhookRequest(server string, subdomainId openapi_types.UUID, body SubdomainWebhookJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainWebhookRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainWebhookRequestWithBody generates requests for SubdomainWebhook with any type of body
+func NewSubdomainWebhookRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/webhook", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubdomainWebhookStatusRequest calls the generic SubdomainWebhookStatus builder with application/json body
+func NewSubdomainWebhookStatusRequest(server string, subdomainId openapi_types.UUID, body SubdomainWebhookStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubdomainWebhookStatusRequestWithBody(server, subdomainId, "application/json", bodyReader)
+}
+
+// NewSubdomainWebhookStatusRequestWithBody generates requests for SubdomainWebhookStatus with any type of body
+func NewSubdomainWebhookStatusRequestWithBody(server string, subdomainId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, subdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subdomain/%s/webhook/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetOverview request
+	GetOverviewWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOverviewResponse, error)
+
+	// GetDiscovery request
+	GetDiscoveryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDiscoveryResponse, error)
+
+	// ReportAbuse request with any body
+	ReportAbuseWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportAbuseResponse, error)
+
+	ReportAbuseWithResponse(ctx context.Context, body ReportAbuseJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportAbuseResponse, error)
+
+	// CreateAccount request with any body
+	CreateAccountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAccountResponse, error)
+
+	CreateAccountWithResponse(ctx context.Context, body CreateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAccountResponse, error)
+
+	// AccountListSubdomains request
+	AccountListSubdomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AccountListSubdomainsResponse, error)
+
+	// AccountAllocateSubdomain request with any body
+	AccountAllocateSubdomainWithBodyWithResponse(ctx context.Context, params *AccountAllocateSubdomainParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AccountAllocateSubdomainResponse, error)
+
+	AccountAllocateSubdomainWithResponse(ctx context.Context, params *AccountAllocateSubdomainParams, body AccountAllocateSubdomainJSONRequestBody, reqEditors ...RequestEditorFn) (*AccountAllocateSubdomainResponse, error)
+
+	// AccountRevokeSubdomains request with any body
+	AccountRevokeSubdomainsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AccountRevokeSubdomainsResponse, error)
+
+	AccountRevokeSubdomainsWithResponse(ctx context.Context, body AccountRevokeSubdomainsJSONRequestBody, reqEditors ...RequestEditorFn) (*AccountRevokeSubdomainsResponse, error)
+
+	// GenerateSubdomain request
+	GenerateSubdomainWithResponse(ctx context.Context, params *GenerateSubdomainParams, reqEditors ...RequestEditorFn) (*GenerateSubdomainResponse, error)
+
+	// SubdomainAcmeChallenge request with any body
+	SubdomainAcmeChallengeWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error)
+
+	SubdomainAcmeChallengeWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error)
+
+	// SubdomainAddressPolicy request with any body
+	SubdomainAddressPolicyWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainAddressPolicyResponse, error)
+
+	SubdomainAddressPolicyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAddressPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainAddressPolicyResponse, error)
+
+	// SubdomainCaa request with any body
+	SubdomainCaaWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainCaaResponse, error)
+
+	SubdomainCaaWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainCaaJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainCaaResponse, error)
+
+	// SubdomainDelegation request with any body
+	SubdomainDelegationWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainDelegationResponse, error)
+
+	SubdomainDelegationWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainDelegationResponse, error)
+
+	// SubdomainClaimLabel request with any body
+	SubdomainClaimLabelWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainClaimLabelResponse, error)
+
+	SubdomainClaimLabelWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainClaimLabelJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainClaimLabelResponse, error)
+
+	// SubdomainService request with any body
+	SubdomainServiceWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainServiceResponse, error)
+
+	SubdomainServiceWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainServiceJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainServiceResponse, error)
+
+	// SubdomainRotateToken request with any body
+	SubdomainRotateTokenWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainRotateTokenResponse, error)
+
+	SubdomainRotateTokenWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainRotateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainRotateTokenResponse, error)
+
+	// SubdomainWebhook request with any body
+	SubdomainWebhookWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainWebhookResponse, error)
+
+	SubdomainWebhookWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainWebhookResponse, error)
+
+	// SubdomainWebhookStatus request with any body
+	SubdomainWebhookStatusWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainWebhookStatusResponse, error)
+
+	SubdomainWebhookStatusWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainWebhookStatusResponse, error)
+}
+
+type GetOverviewResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OverviewResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOverviewResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOverviewResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDiscoveryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DiscoveryResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDiscoveryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDiscoveryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReportAbuseResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AbuseReportResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReportAbuseResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReportAbuseResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AccountListSubdomainsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountSubdomainsResponse
+	JSON401      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AccountListSubdomainsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AccountListSubdomainsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AccountAllocateSubdomainResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NewSubdomainResponse
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AccountAllocateSubdomainResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AccountAllocateSubdomainResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AccountRevokeSubdomainsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountRevokeResponse
+	JSON401      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AccountRevokeSubdomainsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AccountRevokeSubdomainsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GenerateSubdomainResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NewSubdomainResponse
+	JSON400      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GenerateSubdomainResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GenerateSubdomainResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainAcmeChallengeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AcmeChallengeResponse
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainAcmeChallengeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainAcmeChallengeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainAddressPolicyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AddressPolicyResponse
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainAddressPolicyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainAddressPolicyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainCaaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainCaaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainCaaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainDelegationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainDelegationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainDelegationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainClaimLabelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SubdomainLabelResponse
+	JSON400      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainClaimLabelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainClaimLabelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainServiceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainServiceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainServiceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainRotateTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SubdomainTokenResponse
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainRotateTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainRotateTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookResponse
+	JSON400      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubdomainWebhookStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookStatusResponse
+	JSON403      *ErrorResponse
+	JSON429      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubdomainWebhookStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubdomainWebhookStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetOverviewWithResponse request returning *GetOverviewResponse
+func (c *ClientWithResponses) GetOverviewWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOverviewResponse, error) {
+	rsp, err := c.GetOverview(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOverviewResponse(rsp)
+}
+
+// GetDiscoveryWithResponse request returning *GetDiscoveryResponse
+func (c *ClientWithResponses) GetDiscoveryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDiscoveryResponse, error) {
+	rsp, err := c.GetDiscovery(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDiscoveryResponse(rsp)
+}
+
+// ReportAbuseWithBodyWithResponse request with arbitrary body returning *ReportAbuseResponse
+func (c *ClientWithResponses) ReportAbuseWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportAbuseResponse, error) {
+	rsp, err := c.ReportAbuseWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportAbuseResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReportAbuseWithResponse(ctx context.Context, body ReportAbuseJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportAbuseResponse, error) {
+	rsp, err := c.ReportAbuse(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportAbuseResponse(rsp)
+}
+
+// CreateAccountWithBodyWithResponse request with arbitrary body returning *CreateAccountResponse
+func (c *ClientWithResponses) CreateAccountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAccountResponse, error) {
+	rsp, err := c.CreateAccountWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAccountResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAccountWithResponse(ctx context.Context, body CreateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAccountResponse, error) {
+	rsp, err := c.CreateAccount(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAccountResponse(rsp)
+}
+
+// AccountListSubdomainsWithResponse request returning *AccountListSubdomainsResponse
+func (c *ClientWithResponses) AccountListSubdomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AccountListSubdomainsResponse, error) {
+	rsp, err := c.AccountListSubdomains(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAccountListSubdomainsResponse(rsp)
+}
+
+// AccountAllocateSubdomainWithBodyWithResponse request with arbitrary body returning *AccountAllocateSubdomainResponse
+func (c *ClientWithResponses) AccountAllocateSubdomainWithBodyWithResponse(ctx context.Context, params *AccountAllocateSubdomainParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AccountAllocateSubdomainResponse, error) {
+	rsp, err := c.AccountAllocateSubdomainWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAccountAllocateSubdomainResponse(rsp)
+}
+
+func (c *ClientWithResponses) AccountAllocateSubdomainWithResponse(ctx context.Context, params *AccountAllocateSubdomainParams, body AccountAllocateSubdomainJSONRequestBody, reqEditors ...RequestEditorFn) (*AccountAllocateSubdomainResponse, error) {
+	rsp, err := c.AccountAllocateSubdomain(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAccountAllocateSubdomainResponse(rsp)
+}
+
+// AccountRevokeSubdomainsWithBodyWithResponse request with arbitrary body returning *AccountRevokeSubdomainsResponse
+func (c *ClientWithResponses) AccountRevokeSubdomainsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AccountRevokeSubdomainsResponse, error) {
+	rsp, err := c.AccountRevokeSubdomainsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAccountRevokeSubdomainsResponse(rsp)
+}
+
+func (c *ClientWithResponses) AccountRevokeSubdomainsWithResponse(ctx context.Context, body AccountRevokeSubdomainsJSONRequestBody, reqEditors ...RequestEditorFn) (*AccountRevokeSubdomainsResponse, error) {
+	rsp, err := c.AccountRevokeSubdomains(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAccountRevokeSubdomainsResponse(rsp)
+}
+
+// GenerateSubdomainWithResponse request returning *GenerateSubdomainResponse
+func (c *ClientWithResponses) GenerateSubdomainWithResponse(ctx context.Context, params *GenerateSubdomainParams, reqEditors ...RequestEditorFn) (*GenerateSubdomainResponse, error) {
+	rsp, err := c.GenerateSubdomain(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGenerateSubdomainResponse(rsp)
+}
+
+// SubdomainAcmeChallengeWithBodyWithResponse request with arbitrary body returning *SubdomainAcmeChallengeResponse
+func (c *ClientWithResponses) SubdomainAcmeChallengeWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error) {
+	rsp, err := c.SubdomainAcmeChallengeWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainAcmeChallengeResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainAcmeChallengeWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error) {
+	rsp, err := c.SubdomainAcmeChallenge(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainAcmeChallengeResponse(rsp)
+}
+
+// SubdomainAddressPolicyWithBodyWithResponse request with arbitrary body returning *SubdomainAddressPolicyResponse
+func (c *ClientWithResponses) SubdomainAddressPolicyWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainAddressPolicyResponse, error) {
+	rsp, err := c.SubdomainAddressPolicyWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainAddressPolicyResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainAddressPolicyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAddressPolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainAddressPolicyResponse, error) {
+	rsp, err := c.SubdomainAddressPolicy(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainAddressPolicyResponse(rsp)
+}
+
+// SubdomainCaaWithBodyWithResponse request with arbitrary body returning *SubdomainCaaResponse
+func (c *ClientWithResponses) SubdomainCaaWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainCaaResponse, error) {
+	rsp, err := c.SubdomainCaaWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainCaaResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainCaaWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainCaaJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainCaaResponse, error) {
+	rsp, err := c.SubdomainCaa(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainCaaResponse(rsp)
+}
+
+// SubdomainDelegationWithBodyWithResponse request with arbitrary body returning *SubdomainDelegationResponse
+func (c *ClientWithResponses) SubdomainDelegationWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainDelegationResponse, error) {
+	rsp, err := c.SubdomainDelegationWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainDelegationResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainDelegationWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainDelegationJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainDelegationResponse, error) {
+	rsp, err := c.SubdomainDelegation(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainDelegationResponse(rsp)
+}
+
+// SubdomainClaimLabelWithBodyWithResponse request with arbitrary body returning *SubdomainClaimLabelResponse
+func (c *ClientWithResponses) SubdomainClaimLabelWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainClaimLabelResponse, error) {
+	rsp, err := c.SubdomainClaimLabelWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainClaimLabelResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainClaimLabelWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainClaimLabelJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainClaimLabelResponse, error) {
+	rsp, err := c.SubdomainClaimLabel(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainClaimLabelResponse(rsp)
+}
+
+// SubdomainServiceWithBodyWithResponse request with arbitrary body returning *SubdomainServiceResponse
+func (c *ClientWithResponses) SubdomainServiceWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainServiceResponse, error) {
+	rsp, err := c.SubdomainServiceWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainServiceResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainServiceWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainServiceJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainServiceResponse, error) {
+	rsp, err := c.SubdomainService(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainServiceResponse(rsp)
+}
+
+// SubdomainRotateTokenWithBodyWithResponse request with arbitrary body returning *SubdomainRotateTokenResponse
+func (c *ClientWithResponses) SubdomainRotateTokenWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainRotateTokenResponse, error) {
+	rsp, err := c.SubdomainRotateTokenWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainRotateTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainRotateTokenWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainRotateTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainRotateTokenResponse, error) {
+	rsp, err := c.SubdomainRotateToken(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainRotateTokenResponse(rsp)
+}
+
+// SubdomainWebhookWithBodyWithResponse request with arbitrary body returning *SubdomainWebhookResponse
+func (c *ClientWithResponses) SubdomainWebhookWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainWebhookResponse, error) {
+	rsp, err := c.SubdomainWebhookWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainWebhookResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainWebhookWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainWebhookResponse, error) {
+	rsp, err := c.SubdomainWebhook(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainWebhookResponse(rsp)
+}
+
+// SubdomainWebhookStatusWithBodyWithResponse request with arbitrary body returning *SubdomainWebhookStatusResponse
+func (c *ClientWithResponses) SubdomainWebhookStatusWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainWebhookStatusResponse, error) {
+	rsp, err := c.SubdomainWebhookStatusWithBody(ctx, subdomainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainWebhookStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubdomainWebhookStatusWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainWebhookStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainWebhookStatusResponse, error) {
+	rsp, err := c.SubdomainWebhookStatus(ctx, subdomainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubdomainWebhookStatusResponse(rsp)
+}
+
+// ParseGetOverviewResponse parses an HTTP response from a GetOverviewWithResponse call
+func ParseGetOverviewResponse(rsp *http.Response) (*GetOverviewResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOverviewResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OverviewResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDiscoveryResponse parses an HTTP response from a GetDiscoveryWithResponse call
+func ParseGetDiscoveryResponse(rsp *http.Response) (*GetDiscoveryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDiscoveryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DiscoveryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReportAbuseResponse parses an HTTP response from a ReportAbuseWithResponse call
+func ParseReportAbuseResponse(rsp *http.Response) (*ReportAbuseResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReportAbuseResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AbuseReportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAccountResponse parses an HTTP response from a CreateAccountWithResponse call
+func ParseCreateAccountResponse(rsp *http.Response) (*CreateAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAccountListSubdomainsResponse parses an HTTP response from a AccountListSubdomainsWithResponse call
+func ParseAccountListSubdomainsResponse(rsp *http.Response) (*AccountListSubdomainsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AccountListSubdomainsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountSubdomainsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAccountAllocateSubdomainResponse parses an HTTP response from a AccountAllocateSubdomainWithResponse call
+func ParseAccountAllocateSubdomainResponse(rsp *http.Response) (*AccountAllocateSubdomainResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AccountAllocateSubdomainResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NewSubdomainResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAccountRevokeSubdomainsResponse parses an HTTP response from a AccountRevokeSubdomainsWithResponse call
+func ParseAccountRevokeSubdomainsResponse(rsp *http.Response) (*AccountRevokeSubdomainsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AccountRevokeSubdomainsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountRevokeResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGenerateSubdomainResponse parses an HTTP response from a GenerateSubdomainWithResponse call
+func ParseGenerateSubdomainResponse(rsp *http.Response) (*GenerateSubdomainResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GenerateSubdomainResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NewSubdomainResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubdomainAcmeChallengeResponse parses an HTTP response from a SubdomainAcmeChallengeWithResponse call
+func ParseSubdomainAcmeChallengeResponse(rsp *http.Response) (*SubdomainAcmeChallengeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubdomainAcmeChallengeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AcmeChallengeResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubdomainAddressPolicyResponse parses an HTTP response from a SubdomainAddressPolicyWithResponse call
+func ParseSubdomainAddressPolicyResponse(rsp *http.Response) (*SubdomainAddressPolicyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubdomainAddressPolicyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddressPolicyResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return response, nil
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+// ParseSubdomainCaaResponse parses an HTTP response from a SubdomainCaaWithResponse call
+func ParseSubdomainCaaResponse(rsp *http.Response) (*SubdomainCaaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	response := &SubdomainCaaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetOverview request
-	GetOverviewWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOverviewResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-	// GenerateSubdomain request
-	GenerateSubdomainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GenerateSubdomainResponse, error)
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
 
-	// SubdomainAcmeChallenge request with any body
-	SubdomainAcmeChallengeWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error)
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
 
-	SubdomainAcmeChallengeWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error)
-}
+	}
 
-type GetOverviewResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *OverviewResponse
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetOverviewResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseSubdomainDelegationResponse parses an HTTP response from a SubdomainDelegationWithResponse call
+func ParseSubdomainDelegationResponse(rsp *http.Response) (*SubdomainDelegationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetOverviewResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &SubdomainDelegationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GenerateSubdomainResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *NewSubdomainResponse
-	JSON429      *ErrorResponse
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GenerateSubdomainResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GenerateSubdomainResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type SubdomainAcmeChallengeResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON403      *ErrorResponse
-	JSON429      *ErrorResponse
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r SubdomainAcmeChallengeResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseSubdomainClaimLabelResponse parses an HTTP response from a SubdomainClaimLabelWithResponse call
+func ParseSubdomainClaimLabelResponse(rsp *http.Response) (*SubdomainClaimLabelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r SubdomainAcmeChallengeResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &SubdomainClaimLabelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-// GetOverviewWithResponse request returning *GetOverviewResponse
-func (c *ClientWithResponses) GetOverviewWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOverviewResponse, error) {
-	rsp, err := c.GetOverview(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubdomainLabelResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
 	}
-	return ParseGetOverviewResponse(rsp)
+
+	return response, nil
 }
 
-// GenerateSubdomainWithResponse request returning *GenerateSubdomainResponse
-func (c *ClientWithResponses) GenerateSubdomainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GenerateSubdomainResponse, error) {
-	rsp, err := c.GenerateSubdomain(ctx, reqEditors...)
+// ParseSubdomainServiceResponse parses an HTTP response from a SubdomainServiceWithResponse call
+func ParseSubdomainServiceResponse(rsp *http.Response) (*SubdomainServiceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGenerateSubdomainResponse(rsp)
-}
 
-// SubdomainAcmeChallengeWithBodyWithResponse request with arbitrary body returning *SubdomainAcmeChallengeResponse
-func (c *ClientWithResponses) SubdomainAcmeChallengeWithBodyWithResponse(ctx context.Context, subdomainId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error) {
-	rsp, err := c.SubdomainAcmeChallengeWithBody(ctx, subdomainId, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &SubdomainServiceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseSubdomainAcmeChallengeResponse(rsp)
-}
 
-func (c *ClientWithResponses) SubdomainAcmeChallengeWithResponse(ctx context.Context, subdomainId openapi_types.UUID, body SubdomainAcmeChallengeJSONRequestBody, reqEditors ...RequestEditorFn) (*SubdomainAcmeChallengeResponse, error) {
-	rsp, err := c.SubdomainAcmeChallenge(ctx, subdomainId, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
 	}
-	return ParseSubdomainAcmeChallengeResponse(rsp)
+
+	return response, nil
 }
 
-// ParseGetOverviewResponse parses an HTTP response from a GetOverviewWithResponse call
-func ParseGetOverviewResponse(rsp *http.Response) (*GetOverviewResponse, error) {
+// ParseSubdomainRotateTokenResponse parses an HTTP response from a SubdomainRotateTokenWithResponse call
+func ParseSubdomainRotateTokenResponse(rsp *http.Response) (*SubdomainRotateTokenResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetOverviewResponse{
+	response := &SubdomainRotateTokenResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest OverviewResponse
+		var dest SubdomainTokenResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGenerateSubdomainResponse parses an HTTP response from a GenerateSubdomainWithResponse call
-func ParseGenerateSubdomainResponse(rsp *http.Response) (*GenerateSubdomainResponse, error) {
+// ParseSubdomainWebhookResponse parses an HTTP response from a SubdomainWebhookWithResponse call
+func ParseSubdomainWebhookResponse(rsp *http.Response) (*SubdomainWebhookResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GenerateSubdomainResponse{
+	response := &SubdomainWebhookResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest NewSubdomainResponse
+		var dest WebhookResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -507,20 +3152,27 @@ func ParseGenerateSubdomainResponse(rsp *http.Response) (*GenerateSubdomainRespo
 	return response, nil
 }
 
-// ParseSubdomainAcmeChallengeResponse parses an HTTP response from a SubdomainAcmeChallengeWithResponse call
-func ParseSubdomainAcmeChallengeResponse(rsp *http.Response) (*SubdomainAcmeChallengeResponse, error) {
+// ParseSubdomainWebhookStatusResponse parses an HTTP response from a SubdomainWebhookStatusWithResponse call
+func ParseSubdomainWebhookStatusResponse(rsp *http.Response) (*SubdomainWebhookStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SubdomainAcmeChallengeResponse{
+	response := &SubdomainWebhookStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookStatusResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1,2 +1,2 @@
-//go:generate oapi-codegen -package internal -generate types,client -o v1.gen.go ../../../dsdm-v1.yml
+//go:generate oapi-codegen -package internal -generate types,client -o v1.gen.go ../../dsdm-v1.yml
 package internal
@@ -0,0 +1,17 @@
+//go:build !unix && !windows
+
+package dsdm
+
+import (
+	"os"
+)
+
+// File locks are unavailable on this platform, so DirCache.Lock does not
+// exclude other processes.
+func tryLockFile(_ *os.File) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,170 @@
+package dsdm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	legolog "github.com/go-acme/lego/v4/log"
+)
+
+// Logger receives progress messages with alternating key value arguments. It
+// is satisfied by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(_ string, _ ...any) {}
+
+func (nopLogger) Info(_ string, _ ...any) {}
+
+func (nopLogger) Warn(_ string, _ ...any) {}
+
+func (nopLogger) Error(_ string, _ ...any) {}
+
+func loggerOrNop(logger Logger) Logger {
+	if logger == nil {
+		return nopLogger{}
+	}
+
+	return logger
+}
+
+// acmeTransport binds requests made by lego, which does not accept contexts,
+// to the context of the operation and logs them.
+type acmeTransport struct {
+	//nolint:containedctx
+	ctx    context.Context
+	logger Logger
+	base   http.RoundTripper
+}
+
+func (t *acmeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res, err := t.base.RoundTrip(req.WithContext(t.ctx))
+	if err != nil {
+		t.logger.Debug("ACME request failed", "method", req.Method, "url", req.URL.String(), "err", err)
+
+		return nil, err
+	}
+
+	t.logger.Debug("ACME request", "method", req.Method, "url", req.URL.String(), "status", res.StatusCode)
+
+	return res, nil
+}
+
+// legoLogs routes output from lego, which only logs through a package level
+// logger, to the request in flight. It is installed on first use, and passes
+// output to the logger it replaced whenever no request is routing.
+var legoLogs = &legoLogRouter{}
+
+type legoLogRouter struct {
+	once sync.Once
+	// active is held exclusively by a routing request and shared by every
+	// other use of lego, so output is never attributed to the wrong request
+	active sync.RWMutex
+	mu     sync.Mutex
+	prev   legolog.StdLogger
+	target *legoLogTarget
+}
+
+type legoLogTarget struct {
+	logger Logger
+}
+
+// route sends lego output to logger until the returned function is called,
+// dropping it when logger is nil and silence is set. Requests that route wait
+// for all other lego calls of the client to finish, and block them meanwhile.
+// The returned function must be called before route is used again.
+func (r *legoLogRouter) route(logger Logger, silence bool) func() {
+	if logger == nil && !silence {
+		r.active.RLock()
+
+		return r.active.RUnlock
+	}
+
+	r.active.Lock()
+
+	r.once.Do(func() {
+		r.prev = legolog.Logger
+		legolog.Logger = r
+	})
+
+	r.mu.Lock()
+	r.target = &legoLogTarget{logger: logger}
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		r.target = nil
+		r.mu.Unlock()
+
+		r.active.Unlock()
+	}
+}
+
+func (r *legoLogRouter) write(msg string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.target == nil {
+		return false
+	}
+
+	if r.target.logger == nil {
+		return true
+	}
+
+	msg = strings.TrimSuffix(msg, "\n")
+
+	switch {
+	case strings.HasPrefix(msg, "[WARN] "):
+		r.target.logger.Warn(strings.TrimPrefix(msg, "[WARN] "))
+	case strings.HasPrefix(msg, "[INFO] "):
+		r.target.logger.Info(strings.TrimPrefix(msg, "[INFO] "))
+	default:
+		r.target.logger.Info(msg)
+	}
+
+	return true
+}
+
+func (r *legoLogRouter) Fatal(args ...any) {
+	r.prev.Fatal(args...)
+}
+
+func (r *legoLogRouter) Fatalln(args ...any) {
+	r.prev.Fatalln(args...)
+}
+
+func (r *legoLogRouter) Fatalf(format string, args ...any) {
+	r.prev.Fatalf(format, args...)
+}
+
+func (r *legoLogRouter) Print(args ...any) {
+	if !r.write(fmt.Sprint(args...)) {
+		r.prev.Print(args...)
+	}
+}
+
+func (r *legoLogRouter) Println(args ...any) {
+	if !r.write(fmt.Sprintln(args...)) {
+		r.prev.Println(args...)
+	}
+}
+
+func (r *legoLogRouter) Printf(format string, args ...any) {
+	if !r.write(fmt.Sprintf(format, args...)) {
+		r.prev.Printf(format, args...)
+	}
+}
@@ -0,0 +1,513 @@
+package dsdm
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+)
+
+const (
+	managerTimeout       = 5 * time.Minute
+	managerCheckInterval = 12 * time.Hour
+	managerRetryBase     = time.Minute
+	managerRetryMax      = 6 * time.Hour
+)
+
+var ErrInvalidCertificate = errors.New("invalid certificate")
+
+type ManagerEventType string
+
+const (
+	// CertificateLoaded is emitted when a valid certificate is read from the cache.
+	CertificateLoaded ManagerEventType = "loaded"
+	// CertificateObtained is emitted when a certificate was issued.
+	CertificateObtained ManagerEventType = "obtained"
+	// CertificateRenewalScheduled is emitted after each check with the next
+	// renewal time.
+	CertificateRenewalScheduled ManagerEventType = "renewal-scheduled"
+	// CertificateRenewalFailed is emitted when issuance fails. It is retried
+	// after RetryIn while the current certificate continues to be served.
+	CertificateRenewalFailed ManagerEventType = "renewal-failed"
+	// CertificateCacheFailed is emitted when a certificate or account could
+	// not be persisted, and will be issued again after a restart.
+	CertificateCacheFailed ManagerEventType = "cache-failed"
+)
+
+type ManagerEvent struct {
+	Type     ManagerEventType
+	Domain   string
+	NotAfter time.Time
+	RenewAt  time.Time
+	RetryIn  time.Duration
+	Err      error
+}
+
+// Manager keeps a certificate for a subdomain, obtaining it on first use and
+// renewing it in the background while Run is active. Renewal happens within
+// the window suggested by the CA through ACME Renewal Information when
+// supported, and otherwise RenewBefore the certificate expires.
+//
+// Unless Request.Account is set, an ACME account is registered once and
+// stored in the cache alongside the certificate.
+type Manager struct {
+	Client *Client
+	// Request describes the subdomain and the certificate to acquire. A zero
+	// Timeout defaults to five minutes.
+	Request AcquireCertificateRequest
+	// Cache is optional, without it a new certificate is issued on every start.
+	Cache Cache
+	// RenewBefore defaults to a third of the certificate lifetime.
+	RenewBefore time.Duration
+	// OnEvent is called synchronously with renewal events.
+	OnEvent func(ManagerEvent)
+
+	mu   sync.Mutex
+	cert *tls.Certificate
+
+	// obtainMu serialises issuance and guards account
+	obtainMu sync.Mutex
+	account  *ACMEAccount
+}
+
+// TLSConfig returns a tls.Config serving the managed certificate.
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: m.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+// GetCertificate implements tls.Config.GetCertificate. The first handshake
+// blocks until a certificate has been loaded or obtained.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	ctx := hello.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cert, err := m.certificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if hello.ServerName != "" {
+		if err := cert.Leaf.VerifyHostname(hello.ServerName); err != nil {
+			return nil, err
+		}
+	}
+
+	return cert, nil
+}
+
+// Run renews the certificate until the context is cancelled, which is
+// returned as the error.
+func (m *Manager) Run(ctx context.Context) error {
+	retry := managerRetryBase
+
+	for {
+		wait, err := m.maintain(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			m.emit(ManagerEvent{
+				Type:    CertificateRenewalFailed,
+				RetryIn: retry,
+				Err:     err,
+			})
+
+			wait = retry
+
+			retry *= 2
+			if retry > managerRetryMax {
+				retry = managerRetryMax
+			}
+		} else {
+			retry = managerRetryBase
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// maintain renews the certificate when due and returns the time until it
+// should be checked again.
+func (m *Manager) maintain(ctx context.Context) (time.Duration, error) {
+	cert, err := m.certificate(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	m.obtainMu.Lock()
+	defer m.obtainMu.Unlock()
+
+	renewAt := m.renewAt(ctx, cert)
+
+	if wait := time.Until(renewAt); wait > 0 {
+		m.emit(ManagerEvent{
+			Type:     CertificateRenewalScheduled,
+			NotAfter: cert.Leaf.NotAfter,
+			RenewAt:  renewAt,
+		})
+
+		// Checked periodically as renewal information may change
+		if wait > managerCheckInterval {
+			wait = managerCheckInterval
+		}
+
+		return wait, nil
+	}
+
+	unlock, err := m.lockCache(ctx, m.certKey())
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	// Another process sharing the cache may have renewed it already
+	cached, err := m.loadCached(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if cached != nil && cached.Leaf.NotAfter.After(cert.Leaf.NotAfter) && time.Now().Before(m.renewAt(ctx, cached)) {
+		m.setCurrent(cached)
+
+		m.emit(ManagerEvent{
+			Type:     CertificateLoaded,
+			NotAfter: cached.Leaf.NotAfter,
+		})
+
+		return 0, nil
+	}
+
+	if _, err := m.obtain(ctx); err != nil {
+		return 0, err
+	}
+
+	// Avoids renewing continuously when RenewBefore exceeds the lifetime
+	return managerRetryBase, nil
+}
+
+func (m *Manager) certificate(ctx context.Context) (*tls.Certificate, error) {
+	if cert := m.current(); cert != nil {
+		return cert, nil
+	}
+
+	m.obtainMu.Lock()
+	defer m.obtainMu.Unlock()
+
+	if cert := m.current(); cert != nil {
+		return cert, nil
+	}
+
+	unlock, err := m.lockCache(ctx, m.certKey())
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	cert, err := m.loadCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cert != nil {
+		m.setCurrent(cert)
+
+		m.emit(ManagerEvent{
+			Type:     CertificateLoaded,
+			NotAfter: cert.Leaf.NotAfter,
+		})
+
+		return cert, nil
+	}
+
+	return m.obtain(ctx)
+}
+
+// obtain issues a new certificate, with obtainMu held.
+func (m *Manager) obtain(ctx context.Context) (*tls.Certificate, error) {
+	account, err := m.acmeAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := m.Request
+	request.Account = account
+
+	if request.Timeout == 0 {
+		request.Timeout = managerTimeout
+	}
+
+	res, err := m.Client.AcquireCertificate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, len(res.PrivateKey)+len(res.Certificate))
+	data = append(data, res.PrivateKey...)
+	data = append(data, res.Certificate...)
+
+	cert, err := parseCertificate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m.setCurrent(cert)
+
+	m.emit(ManagerEvent{
+		Type:     CertificateObtained,
+		NotAfter: cert.Leaf.NotAfter,
+	})
+
+	if m.Cache != nil {
+		if err := m.Cache.Put(ctx, m.certKey(), data); err != nil {
+			m.emit(ManagerEvent{
+				Type: CertificateCacheFailed,
+				Err:  err,
+			})
+		}
+	}
+
+	return cert, nil
+}
+
+// loadCached returns the certificate stored in the cache, or nil when there
+// is no valid one.
+func (m *Manager) loadCached(ctx context.Context) (*tls.Certificate, error) {
+	if m.Cache == nil {
+		return nil, nil
+	}
+
+	data, err := m.Cache.Get(ctx, m.certKey())
+	if errors.Is(err, ErrCacheMiss) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	cert, err := parseCertificate(data)
+	if err != nil || !time.Now().Before(cert.Leaf.NotAfter) {
+		return nil, nil //nolint:nilerr
+	}
+
+	return cert, nil
+}
+
+func (m *Manager) lockCache(ctx context.Context, key string) (func(), error) {
+	if m.Cache == nil {
+		return func() {}, nil
+	}
+
+	return lockCache(ctx, m.Cache, key)
+}
+
+// acmeAccount returns the account used for issuance, with obtainMu held.
+func (m *Manager) acmeAccount(ctx context.Context) (*ACMEAccount, error) {
+	if m.Request.Account != nil {
+		return m.Request.Account, nil
+	}
+
+	if m.account != nil {
+		return m.account, nil
+	}
+
+	provider, err := resolveProvider(m.Request.Provider, m.Request.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	key := accountCacheKey(provider.directory)
+
+	unlock, err := m.lockCache(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	if m.Cache != nil {
+		data, err := m.Cache.Get(ctx, key)
+
+		switch {
+		case errors.Is(err, ErrCacheMiss):
+		case err != nil:
+			return nil, err
+		default:
+			var loaded ACMEAccount
+
+			if err := json.Unmarshal(data, &loaded); err != nil {
+				return nil, err
+			}
+
+			m.account = &loaded
+
+			return &loaded, nil
+		}
+	}
+
+	account, err := m.Client.NewACMEAccount(ctx, ACMEAccountRequest{
+		Provider:   m.Request.Provider,
+		Directory:  m.Request.Directory,
+		Email:      m.Request.Email,
+		EAB:        m.Request.EAB,
+		RootCAs:    m.Request.RootCAs,
+		Timeout:    m.Request.Timeout,
+		Logger:     m.Request.Logger,
+		SilenceLog: m.Request.SilenceLog,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.account = account
+
+	if m.Cache != nil {
+		data, err := json.Marshal(account)
+		if err == nil {
+			err = m.Cache.Put(ctx, key, data)
+		}
+
+		if err != nil {
+			m.emit(ManagerEvent{
+				Type: CertificateCacheFailed,
+				Err:  err,
+			})
+		}
+	}
+
+	return account, nil
+}
+
+func (m *Manager) renewAt(ctx context.Context, cert *tls.Certificate) time.Time {
+	leaf := cert.Leaf
+
+	if renewAt, ok := m.suggestedRenewal(ctx, cert); ok {
+		return renewAt
+	}
+
+	before := m.RenewBefore
+	if before == 0 {
+		before = leaf.NotAfter.Sub(leaf.NotBefore) / 3
+	}
+
+	return leaf.NotAfter.Add(-before)
+}
+
+// suggestedRenewal returns a time within the renewal window suggested by the
+// CA, if it supports ACME Renewal Information.
+func (m *Manager) suggestedRenewal(ctx context.Context, cert *tls.Certificate) (time.Time, bool) {
+	if len(cert.Certificate) < 2 {
+		return time.Time{}, false
+	}
+
+	issuer, err := x509.ParseCertificate(cert.Certificate[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	account, err := m.acmeAccount(ctx)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	defer legoLogs.route(m.Request.Logger, m.Request.SilenceLog)()
+
+	client, err := lego.NewClient(m.Client.newLegoConfig(ctx, account, m.Request.RootCAs, m.Request.Logger))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	info, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{
+		Cert:     cert.Leaf,
+		Issuer:   issuer,
+		HashName: crypto.SHA256.String(),
+	})
+	if err != nil || !info.SuggestedWindow.End.After(info.SuggestedWindow.Start) {
+		return time.Time{}, false
+	}
+
+	renewAt := info.ShouldRenewAt(time.Now(), time.Until(info.SuggestedWindow.End))
+	if renewAt == nil {
+		return time.Time{}, false
+	}
+
+	return *renewAt, true
+}
+
+func (m *Manager) current() *tls.Certificate {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.cert
+}
+
+func (m *Manager) setCurrent(cert *tls.Certificate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cert = cert
+}
+
+func (m *Manager) emit(event ManagerEvent) {
+	if m.OnEvent == nil {
+		return
+	}
+
+	event.Domain = m.Request.Domain
+
+	m.OnEvent(event)
+}
+
+func (m *Manager) certKey() string {
+	if len(m.Request.Names) == 0 {
+		return "cert-" + m.Request.Domain
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(m.Request.Names, ",")))
+
+	return "cert-" + m.Request.Domain + "-" + hex.EncodeToString(sum[:8])
+}
+
+func accountCacheKey(directory string) string {
+	sum := sha256.Sum256([]byte(directory))
+
+	return "acme-account-" + hex.EncodeToString(sum[:8])
+}
+
+// parseCertificate parses a PEM encoded private key followed by the
+// certificate chain.
+func parseCertificate(data []byte) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(data, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+
+	return &cert, nil
+}
@@ -0,0 +1,68 @@
+package dsdm
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+const clientUserAgent = "dsdm-go-client/1.0"
+
+type Option func(*Client)
+
+// WithHTTPClient sets the client used for requests to the DSDM server, the
+// ACME CA and ZeroSSL, such as one configured with a proxy or client
+// certificates.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithUserAgent appends a product token, such as "myapp/1.2", to the user
+// agent of the client.
+func WithUserAgent(product string) Option {
+	return func(c *Client) {
+		c.userAgent += " " + product
+	}
+}
+
+// WithHeader adds a header to requests made to the DSDM server. Headers are
+// not sent to third parties such as ZeroSSL.
+func WithHeader(key string, value string) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+
+		c.headers.Add(key, value)
+	}
+}
+
+// WithTimeout bounds each request, including reading the response. Retried
+// requests are bounded individually.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// httpClientWithTimeout returns a copy of hc with the timeout applied.
+func httpClientWithTimeout(hc *http.Client, timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		return hc
+	}
+
+	copied := *hc
+	copied.Timeout = timeout
+
+	return &copied
+}
+
+func (c *Client) applyHeaders(req *http.Request) {
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	req.Header.Set("User-Agent", strings.TrimSpace(c.userAgent))
+}
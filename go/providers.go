@@ -0,0 +1,60 @@
+package dsdm
+
+import (
+	"github.com/go-acme/lego/v4/lego"
+)
+
+const (
+	ProviderLetsEncrypt        = "letsencrypt"
+	ProviderLetsEncryptStaging = "letsencrypt-staging"
+	ProviderBuypass            = "buypass"
+	ProviderBuypassStaging     = "buypass-staging"
+	ProviderGoogle             = "google"
+	ProviderGoogleStaging      = "google-staging"
+	// ProviderCustom uses the directory, EAB credentials and root CAs given in
+	// the request, for example to test against a local Pebble instance.
+	ProviderCustom = "custom"
+)
+
+type acmeProvider struct {
+	directory     string
+	caaIdentifier string
+	requiresEAB   bool
+	requiresEmail bool
+}
+
+var acmeProviders = map[string]acmeProvider{
+	ProviderZeroSSL: {
+		directory:     zeroSSLURL,
+		caaIdentifier: zeroSSLCAIdentifier,
+		requiresEmail: true,
+	},
+	ProviderLetsEncrypt: {
+		directory:     lego.LEDirectoryProduction,
+		caaIdentifier: "letsencrypt.org",
+	},
+	ProviderLetsEncryptStaging: {
+		directory:     lego.LEDirectoryStaging,
+		caaIdentifier: "letsencrypt.org",
+	},
+	ProviderBuypass: {
+		directory:     "https://api.buypass.com/acme/directory",
+		caaIdentifier: "buypass.com",
+		requiresEmail: true,
+	},
+	ProviderBuypassStaging: {
+		directory:     "https://api.test4.buypass.no/acme/directory",
+		caaIdentifier: "buypass.com",
+		requiresEmail: true,
+	},
+	ProviderGoogle: {
+		directory:     "https://dv.acme-v02.api.pki.goog/directory",
+		caaIdentifier: "pki.goog",
+		requiresEAB:   true,
+	},
+	ProviderGoogleStaging: {
+		directory:     "https://dv.acme-v02.test-api.pki.goog/directory",
+		caaIdentifier: "pki.goog",
+		requiresEAB:   true,
+	},
+}
@@ -0,0 +1,176 @@
+package dsdm
+
+import (
+	"context"
+	"io"
+	"math/bits"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/csnewman/dyndirect/go/internal"
+)
+
+// DefaultRetryPolicy provides the values used for unset RetryPolicy fields.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// RetryPolicy configures how failed requests are retried. Rate limited
+// responses are retried for every call, while transport errors and server
+// errors are only retried for calls that are safe to repeat.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt.
+	MaxAttempts int
+	// BaseDelay is doubled after each attempt, with jitter applied.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. A Retry-After exceeding it
+	// is returned to the caller as an error instead.
+	MaxDelay time.Duration
+}
+
+// WithRetryPolicy enables retries, which are disabled by default.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+type idempotentKey struct{}
+
+// idempotent marks requests made with ctx as safe to repeat, for calls that
+// replace state rather than create it.
+func idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+type retryDoer struct {
+	doer   internal.HttpRequestDoer
+	policy RetryPolicy
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	safe := req.Method == http.MethodGet || req.Method == http.MethodHead || ctx.Value(idempotentKey{}) != nil
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		res, err := d.doer.Do(req)
+
+		if attempt >= d.policy.MaxAttempts || !replayable {
+			return res, err
+		}
+
+		wait := d.policy.backoff(attempt)
+
+		switch {
+		case err != nil:
+			if !safe || ctx.Err() != nil {
+				return nil, err
+			}
+		case res.StatusCode == http.StatusTooManyRequests || (safe && retryableStatus(res.StatusCode)):
+			if after, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+				if after > d.policy.MaxDelay {
+					return res, nil
+				}
+
+				if after > wait {
+					wait = after
+				}
+			}
+
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		default:
+			return res, nil
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MaxDelay
+
+	// Shifting by less than the bit length of the ratio stays within MaxDelay,
+	// so the shift can never overflow
+	if shift := attempt - 1; shift < bits.Len64(uint64(p.MaxDelay/p.BaseDelay)) {
+		delay = p.BaseDelay << shift
+	}
+
+	// Full jitter over the upper half, so concurrent clients spread out
+	half := int64(delay / 2)
+
+	//nolint:gosec
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter accepts both forms of the header, delay seconds and an
+// HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if d := time.Until(at); d > 0 {
+		return d, true
+	}
+
+	return 0, true
+}
@@ -0,0 +1,156 @@
+package dsdm
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/csnewman/dyndirect/go/internal"
+	"github.com/google/uuid"
+)
+
+// WebhookTolerance is the maximum age of a delivery accepted by ParseWebhook.
+const WebhookTolerance = 5 * time.Minute
+
+var (
+	ErrWebhookSignature = errors.New("invalid webhook signature")
+	ErrWebhookExpired   = errors.New("webhook timestamp outside tolerance")
+)
+
+type WebhookEvent = internal.WebhookEvent
+
+const (
+	EventWebhookSet         WebhookEvent = internal.WebhookSet
+	EventACMESet            WebhookEvent = internal.AcmeSet
+	EventLabelClaimed       WebhookEvent = internal.LabelClaimed
+	EventDelegationSet      WebhookEvent = internal.DelegationSet
+	EventAddressPolicySet   WebhookEvent = internal.AddressPolicySet
+	EventServiceSet         WebhookEvent = internal.ServiceSet
+	EventCAASet             WebhookEvent = internal.CaaSet
+	EventSubdomainBlocked   WebhookEvent = internal.SubdomainBlocked
+	EventSubdomainUnblocked WebhookEvent = internal.SubdomainUnblocked
+	EventSubdomainRevoked   WebhookEvent = internal.SubdomainRevoked
+	EventTokenRotated       WebhookEvent = internal.TokenRotated
+)
+
+type WebhookDeliveryStatus = internal.WebhookDeliveryStatus
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = internal.Pending
+	DeliveryDelivered WebhookDeliveryStatus = internal.Delivered
+	DeliveryFailed    WebhookDeliveryStatus = internal.Failed
+)
+
+type SubdomainWebhookRequest struct {
+	ID     uuid.UUID
+	Token  string
+	URL    string
+	Events []WebhookEvent
+}
+
+type WebhookResponse = internal.WebhookResponse
+
+type WebhookStatusResponse = internal.WebhookStatusResponse
+
+type WebhookDelivery = internal.WebhookDelivery
+
+// WebhookPayload is the body posted to a webhook.
+type WebhookPayload struct {
+	ID          uuid.UUID    `json:"id"`
+	Event       WebhookEvent `json:"event"`
+	SubdomainID uuid.UUID    `json:"subdomain_id"` //nolint:tagliatelle
+	Time        time.Time    `json:"time"`
+	Detail      string       `json:"detail,omitempty"`
+}
+
+func (c *Client) SetSubdomainWebhook(ctx context.Context, req SubdomainWebhookRequest) (*WebhookResponse, error) {
+	body := internal.SubdomainWebhookRequest{
+		Token: req.Token,
+	}
+
+	if req.URL != "" {
+		body.Url = &req.URL
+	}
+
+	if len(req.Events) > 0 {
+		body.Events = &req.Events
+	}
+
+	resp, err := c.v1.SubdomainWebhook(ctx, req.ID, body, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[WebhookResponse](resp)
+}
+
+func (c *Client) ClearSubdomainWebhook(ctx context.Context, id uuid.UUID, token string) error {
+	_, err := c.SetSubdomainWebhook(ctx, SubdomainWebhookRequest{
+		ID:    id,
+		Token: token,
+	})
+
+	return err
+}
+
+func (c *Client) GetSubdomainWebhookStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	token string,
+) (*WebhookStatusResponse, error) {
+	resp, err := c.v1.SubdomainWebhookStatus(idempotent(ctx), id, internal.SubdomainWebhookStatusRequest{
+		Token: token,
+	}, c.requestHook)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse[WebhookStatusResponse](resp)
+}
+
+// ParseWebhook verifies the signature of a webhook request using the secret
+// returned when the webhook was registered, and decodes its payload.
+// Deliveries may be retried, so receivers should deduplicate by ID.
+func ParseWebhook(r *http.Request, secret string) (*WebhookPayload, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := r.Header.Get("X-DSDM-Timestamp")
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get("X-DSDM-Signature"))) {
+		return nil, ErrWebhookSignature
+	}
+
+	age := time.Since(time.Unix(unix, 0))
+	if age > WebhookTolerance || age < -WebhookTolerance {
+		return nil, ErrWebhookExpired
+	}
+
+	var payload WebhookPayload
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
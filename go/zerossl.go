@@ -8,12 +8,14 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
 const (
 	zeroSSLAccountEndpoint = "https://api.zerossl.com/acme/eab-credentials-email"
 	zeroSSLURL             = "https://acme.zerossl.com/v2/DV90"
+	zeroSSLCAIdentifier    = "sectigo.com"
 	ProviderZeroSSL        = "zerossl"
 )
 
@@ -27,20 +29,27 @@ type zeroSSLAccountResp struct {
 	EABHMACKey string `json:"eab_hmac_key"`
 }
 
-func generateZeroSslAccount(ctx context.Context, email string, timeout time.Duration) (*zeroSSLAccountResp, error) {
+func (c *Client) generateZeroSslAccount(
+	ctx context.Context,
+	email string,
+	timeout time.Duration,
+) (*zeroSSLAccountResp, error) {
 	form := url.Values{"email": {email}}
 	formReader := bytes.NewReader([]byte(form.Encode()))
 
-	hc := &http.Client{
-		Timeout: timeout,
+	if timeout <= 0 {
+		timeout = c.timeout
 	}
 
+	hc := httpClientWithTimeout(c.httpClient, timeout)
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, zeroSSLAccountEndpoint, formReader)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", strings.TrimSpace(c.userAgent))
 
 	resp, err := hc.Do(req)
 	if err != nil {
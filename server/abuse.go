@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	activityLimit       = 50
+	activeSubdomainsMax = 10000
+	abuseReportWindow   = time.Hour
+)
+
+type SubdomainBlock struct {
+	ID     uuid.UUID `json:"id"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+type Activity struct {
+	Time   time.Time `json:"time"`
+	Event  string    `json:"event"`
+	IP     string    `json:"ip,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+type AbuseReport struct {
+	ID          uuid.UUID `json:"id"`
+	Time        time.Time `json:"time"`
+	Domain      string    `json:"domain"`
+	SubdomainID uuid.UUID `json:"subdomain_id"` //nolint:tagliatelle
+	Reason      string    `json:"reason"`
+	Contact     string    `json:"contact,omitempty"`
+	ReporterIP  string    `json:"reporter_ip"` //nolint:tagliatelle
+}
+
+func (s *Server) addressForbidden(ctx context.Context, ip net.IP) (bool, error) {
+	for _, n := range s.forbiddenNets {
+		if n.Contains(ip) {
+			return true, nil
+		}
+	}
+
+	cidrs, err := s.store.GetForbiddenCIDRs(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+
+		if n.Contains(ip) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	v1 "github.com/csnewman/dyndirect/server/internal/v1"
+	"github.com/google/uuid"
+)
+
+const (
+	EventSubdomainRevoked = "subdomain_revoked"
+
+	accountKeyPrefix    = "dsdm_"
+	accountKeyBytes     = 32
+	accountCreateWindow = time.Hour
+)
+
+type Account struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name,omitempty"`
+	KeyHash string    `json:"key_hash"` //nolint:tagliatelle
+	Created time.Time `json:"created"`
+}
+
+type AccountSubdomain struct {
+	ID          uuid.UUID  `json:"id"`
+	Domain      string     `json:"domain"`
+	Description string     `json:"description,omitempty"`
+	Created     time.Time  `json:"created"`
+	Revoked     *time.Time `json:"revoked,omitempty"`
+}
+
+func generateAccountKey() (string, error) {
+	buf := make([]byte, accountKeyBytes)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return accountKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashAccountKey returns the form keys are stored and looked up by, so a
+// store dump does not reveal usable keys.
+func hashAccountKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (v *v1API) requestAccount(ctx context.Context) (*Account, error) {
+	r, ok := requestFromCtx(ctx)
+	if !ok {
+		return nil, errRequestMissingInCtx
+	}
+
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !strings.HasPrefix(key, accountKeyPrefix) {
+		return nil, nil
+	}
+
+	return v.store.GetAccount(ctx, hashAccountKey(key))
+}
+
+func (v *v1API) authorizeAccount(ctx context.Context) (*Account, *v1.ErrorResponse, error) {
+	account, err := v.requestAccount(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if account == nil {
+		v.store.IncrementStat(ctx, "api_account_key_invalid", 1)
+
+		return nil, &v1.ErrorResponse{
+			Error:   "invalid-account-key",
+			Message: "A valid account key is required.",
+		}, nil
+	}
+
+	return account, nil, nil
+}
+
+func (v *v1API) ownedByRequestAccount(ctx context.Context, id uuid.UUID) (bool, error) {
+	account, err := v.requestAccount(ctx)
+	if err != nil || account == nil {
+		return false, err
+	}
+
+	owner, err := v.store.GetSubdomainAccount(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return owner == account.ID, nil
+}
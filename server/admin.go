@@ -0,0 +1,371 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type adminSubdomain struct {
+	ID       uuid.UUID       `json:"id"`
+	Account  *uuid.UUID      `json:"account,omitempty"`
+	Block    *SubdomainBlock `json:"block,omitempty"`
+	Revoked  *time.Time      `json:"revoked,omitempty"`
+	Activity []Activity      `json:"activity"`
+}
+
+type adminBlockRequest struct {
+	Reason string `json:"reason"`
+}
+
+type adminCIDRs struct {
+	Config  []string `json:"config"`
+	Dynamic []string `json:"dynamic"`
+}
+
+type adminCIDRsRequest struct {
+	CIDRs []string `json:"cidrs"`
+}
+
+func (s *Server) buildAdminServer() *http.Server {
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(s.httpLogger)
+	r.Use(middleware.NoCache)
+	r.Use(render.SetContentType(render.ContentTypeJSON))
+	r.Use(s.httpRecoverer)
+	r.Use(middleware.Timeout(5 * time.Second))
+	r.Use(s.adminAuth)
+
+	r.Get("/subdomains", s.adminListSubdomains)
+	r.Get("/subdomains/{subdomain}", s.adminGetSubdomain)
+	r.Put("/subdomains/{subdomain}/block", s.adminBlockSubdomain)
+	r.Delete("/subdomains/{subdomain}/block", s.adminUnblockSubdomain)
+	r.Get("/blocks", s.adminListBlocks)
+	r.Get("/forbidden-cidrs", s.adminGetCIDRs)
+	r.Put("/forbidden-cidrs", s.adminSetCIDRs)
+	r.Get("/reports", s.adminListReports)
+	r.Delete("/reports/{report}", s.adminDeleteReport)
+	r.Get("/cluster", s.adminClusterStatus)
+
+	return &http.Server{
+		Addr:         s.cfg.AdminListen,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+		IdleTimeout:  30 * time.Second,
+		Handler:      r,
+	}
+}
+
+func (s *Server) adminAuth(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + s.cfg.AdminToken)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			writeResponse(
+				w, r, http.StatusUnauthorized,
+				"unauthorized",
+				"A valid admin token is required",
+			)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) adminListSubdomains(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > activeSubdomainsMax {
+			writeResponse(w, r, http.StatusBadRequest, "bad-request", "Invalid limit")
+
+			return
+		}
+
+		limit = parsed
+	}
+
+	ids, err := s.store.ListActiveSubdomains(r.Context(), limit)
+	if err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	res := make([]adminSubdomain, 0, len(ids))
+
+	for _, id := range ids {
+		sub, err := s.adminLoadSubdomain(r.Context(), id)
+		if err != nil {
+			s.adminError(w, r, err)
+
+			return
+		}
+
+		res = append(res, *sub)
+	}
+
+	render.JSON(w, r, res)
+}
+
+func (s *Server) adminGetSubdomain(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.adminSubdomainParam(w, r)
+	if !ok {
+		return
+	}
+
+	sub, err := s.adminLoadSubdomain(r.Context(), id)
+	if err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	render.JSON(w, r, sub)
+}
+
+func (s *Server) adminBlockSubdomain(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.adminSubdomainParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req adminBlockRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeResponse(w, r, http.StatusBadRequest, "bad-request", "Invalid request body")
+
+		return
+	}
+
+	block := SubdomainBlock{
+		ID:     id,
+		Reason: req.Reason,
+		Time:   time.Now(),
+	}
+
+	if err := s.store.BlockSubdomain(r.Context(), block); err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	s.logger.Infow("Subdomain blocked", "id", id, "reason", req.Reason)
+	s.store.IncrementStat(r.Context(), "admin_block", 1)
+
+	if err := s.webhooks.notify(r.Context(), id, EventSubdomainBlocked, req.Reason); err != nil {
+		s.logger.Warnw("Failed to queue webhook", "id", id, "err", err)
+	}
+
+	render.JSON(w, r, block)
+}
+
+func (s *Server) adminUnblockSubdomain(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.adminSubdomainParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.store.UnblockSubdomain(r.Context(), id); err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	s.logger.Infow("Subdomain unblocked", "id", id)
+
+	if err := s.webhooks.notify(r.Context(), id, EventSubdomainUnblocked, ""); err != nil {
+		s.logger.Warnw("Failed to queue webhook", "id", id, "err", err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) adminListBlocks(w http.ResponseWriter, r *http.Request) {
+	blocks, err := s.store.ListSubdomainBlocks(r.Context())
+	if err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	render.JSON(w, r, blocks)
+}
+
+func (s *Server) adminGetCIDRs(w http.ResponseWriter, r *http.Request) {
+	cidrs, err := s.store.GetForbiddenCIDRs(r.Context())
+	if err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	render.JSON(w, r, &adminCIDRs{
+		Config:  s.cfg.ForbiddenCIDRs,
+		Dynamic: cidrs,
+	})
+}
+
+func (s *Server) adminSetCIDRs(w http.ResponseWriter, r *http.Request) {
+	var req adminCIDRsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeResponse(w, r, http.StatusBadRequest, "bad-request", "Invalid request body")
+
+		return
+	}
+
+	cidrs := make([]string, 0, len(req.CIDRs))
+
+	for _, cidr := range req.CIDRs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			writeResponse(w, r, http.StatusBadRequest, "bad-request", "Invalid CIDR "+cidr)
+
+			return
+		}
+
+		cidrs = append(cidrs, n.String())
+	}
+
+	if err := s.store.SetForbiddenCIDRs(r.Context(), cidrs); err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	s.logger.Infow("Forbidden CIDRs updated", "cidrs", cidrs)
+
+	render.JSON(w, r, &adminCIDRs{
+		Config:  s.cfg.ForbiddenCIDRs,
+		Dynamic: cidrs,
+	})
+}
+
+func (s *Server) adminListReports(w http.ResponseWriter, r *http.Request) {
+	reports, err := s.store.ListAbuseReports(r.Context())
+	if err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	render.JSON(w, r, reports)
+}
+
+func (s *Server) adminDeleteReport(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "report"))
+	if err != nil {
+		writeResponse(w, r, http.StatusBadRequest, "bad-request", "Invalid report ID")
+
+		return
+	}
+
+	if err := s.store.DeleteAbuseReport(r.Context(), id); err != nil {
+		s.adminError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) adminClusterStatus(w http.ResponseWriter, r *http.Request) {
+	cached, ok := s.store.(*CachedStore)
+	if !ok {
+		writeResponse(
+			w, r, http.StatusNotFound,
+			"cluster-disabled",
+			"Cluster mode is not enabled",
+		)
+
+		return
+	}
+
+	render.JSON(w, r, cached.Status())
+}
+
+func (s *Server) adminSubdomainParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	label := strings.ToLower(chi.URLParam(r, "subdomain"))
+
+	id, err := resolveSubdomain(r.Context(), s.store, label)
+	if err != nil {
+		s.adminError(w, r, err)
+
+		return uuid.Nil, false
+	}
+
+	if id == uuid.Nil {
+		writeResponse(w, r, http.StatusNotFound, "not-found", "Unknown subdomain")
+
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func (s *Server) adminLoadSubdomain(ctx context.Context, id uuid.UUID) (*adminSubdomain, error) {
+	block, err := s.store.GetSubdomainBlock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.store.GetSubdomainRevocation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.store.GetActivity(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &adminSubdomain{
+		ID:       id,
+		Block:    block,
+		Revoked:  revoked,
+		Activity: activity,
+	}
+
+	account, err := s.store.GetSubdomainAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if account != uuid.Nil {
+		sub.Account = &account
+	}
+
+	return sub, nil
+}
+
+func (s *Server) adminError(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.WithOptions(zap.AddStacktrace(zapcore.NewNopCore())).Errorw(
+		"Admin Request Error",
+		"path", r.URL.Path,
+		"request_id", middleware.GetReqID(r.Context()),
+		"err", err,
+	)
+
+	writeResponse(
+		w, r, http.StatusInternalServerError,
+		"internal-error",
+		"An internal server error has occurred",
+	)
+}
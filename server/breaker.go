@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	breakerThreshold = 5
+	breakerCooldown  = 10 * time.Second
+)
+
+var ErrStoreUnavailable = errors.New("store unavailable")
+
+// circuitBreaker fails redis commands fast once the server has stopped
+// responding, so DNS queries are not held up waiting on timeouts.
+type circuitBreaker struct {
+	logger    *zap.SugaredLogger
+	threshold int
+	cooldown  time.Duration
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(logger *zap.SugaredLogger, cfg Config) *circuitBreaker {
+	b := &circuitBreaker{
+		logger:    logger,
+		threshold: cfg.RedisBreakerThreshold,
+		cooldown:  cfg.RedisBreakerCooldown,
+	}
+
+	if b.threshold <= 0 {
+		b.threshold = breakerThreshold
+	}
+
+	if b.cooldown <= 0 {
+		b.cooldown = breakerCooldown
+	}
+
+	return b
+}
+
+func (b *circuitBreaker) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if !b.allow() {
+			return nil, ErrStoreUnavailable
+		}
+
+		return next(ctx, network, addr)
+	}
+}
+
+func (b *circuitBreaker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !b.allow() {
+			return ErrStoreUnavailable
+		}
+
+		err := next(ctx, cmd)
+		b.record(err)
+
+		return err
+	}
+}
+
+func (b *circuitBreaker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !b.allow() {
+			return ErrStoreUnavailable
+		}
+
+		err := next(ctx, cmds)
+		b.record(err)
+
+		return err
+	}
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return time.Now().After(b.openUntil)
+}
+
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replyErr redis.Error
+
+	switch {
+	case err == nil, errors.As(err, &replyErr):
+		// Missing keys and error replies show the server is reachable
+		b.failures = 0
+
+		return
+	case errors.Is(err, ErrStoreUnavailable), errors.Is(err, context.Canceled):
+		return
+	}
+
+	b.failures++
+
+	if b.failures >= b.threshold {
+		if time.Now().After(b.openUntil) {
+			b.logger.Warnw("Redis unavailable, failing fast", "cooldown", b.cooldown, "err", err)
+		}
+
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/url"
+	"strings"
+
+	v1 "github.com/csnewman/dyndirect/server/internal/v1"
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+const caaTTL = 300
+
+type CAARecord struct {
+	Flag  uint8  `json:"flag"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+func parseCAA(req *v1.SubdomainCAARequest) ([]CAARecord, error) {
+	records := make([]CAARecord, 0, len(req.Records))
+
+	for _, record := range req.Records {
+		tag := string(record.Tag)
+
+		switch record.Tag {
+		case v1.Issue, v1.Issuewild:
+			if err := validateIssuer(record.Value); err != nil {
+				return nil, err
+			}
+		case v1.Iodef:
+			u, err := url.Parse(record.Value)
+			if err != nil || (u.Scheme != "mailto" && u.Scheme != "https" && u.Scheme != "http") {
+				return nil, errors.Errorf("iodef value '%s' must be a mailto:, http: or https: URL", record.Value)
+			}
+		default:
+			return nil, errors.Errorf("tag '%s' is not supported", tag)
+		}
+
+		records = append(records, CAARecord{
+			Flag:  uint8(record.Flag),
+			Tag:   tag,
+			Value: record.Value,
+		})
+	}
+
+	return records, nil
+}
+
+// validateIssuer checks an issue or issuewild value of the form
+// "ca.example; key=value; ...", where an empty domain forbids issuance.
+func validateIssuer(value string) error {
+	parts := strings.Split(value, ";")
+
+	issuer := strings.TrimSpace(parts[0])
+	if issuer != "" {
+		if _, ok := dns.IsDomainName(issuer); !ok || strings.HasSuffix(issuer, ".") || strings.ContainsAny(issuer, " \t") {
+			return errors.Errorf("issuer '%s' is not a valid domain name", issuer)
+		}
+	}
+
+	for _, param := range parts[1:] {
+		key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || key == "" || val == "" || strings.ContainsAny(val, " \t") {
+			return errors.Errorf("parameter '%s' must be of the form key=value", strings.TrimSpace(param))
+		}
+
+		if key == "accounturi" {
+			if u, err := url.Parse(val); err != nil || u.Scheme != "https" {
+				return errors.Errorf("accounturi '%s' must be a https URL", val)
+			}
+		}
+	}
+
+	return nil
+}
+
+func caaAnswers(q dns.Question, records []CAARecord) []dns.RR {
+	answers := make([]dns.RR, 0, len(records))
+
+	for _, record := range records {
+		answers = append(answers, &dns.CAA{
+			Hdr:   dns.RR_Header{Name: q.Name, Rrtype: dns.TypeCAA, Class: q.Qclass, Ttl: caaTTL},
+			Flag:  record.Flag,
+			Tag:   record.Tag,
+			Value: record.Value,
+		})
+	}
+
+	return answers
+}
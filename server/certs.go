@@ -0,0 +1,384 @@
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	ChallengeHTTP01 = "http-01"
+	ChallengeDNS01  = "dns-01"
+
+	certRenewBefore   = 30 * 24 * time.Hour
+	certCheckInterval = 12 * time.Hour
+	certRetryInterval = time.Hour
+
+	accountKeyFile  = "acme-account.key"
+	accountFile     = "acme-account.json"
+	challengePrefix = "_acme-challenge."
+)
+
+var errNoCertificate = errors.New("certificate contains no leaf")
+
+type certAccount struct {
+	Directory    string                 `json:"directory"`
+	Email        string                 `json:"email"`
+	Registration *registration.Resource `json:"registration"`
+	key          crypto.PrivateKey
+}
+
+func (a *certAccount) GetEmail() string {
+	return a.Email
+}
+
+func (a *certAccount) GetRegistration() *registration.Resource {
+	return a.Registration
+}
+
+func (a *certAccount) GetPrivateKey() crypto.PrivateKey {
+	return a.key
+}
+
+// certManager obtains and renews the API certificate using DNS-01, answering
+// the challenges from the server's own zone.
+type certManager struct {
+	logger    *zap.SugaredLogger
+	store     Store
+	dir       string
+	directory string
+	contact   string
+	ttl       time.Duration
+	domains   []string
+	name      string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func newCertManager(s *Server) *certManager {
+	directory := s.cfg.ACMEDirectory
+	if directory == "" {
+		directory = lego.LEDirectoryProduction
+	}
+
+	root := strings.TrimSuffix(strings.ToLower(s.cfg.RootDomain), ".")
+
+	return &certManager{
+		logger:    s.logger,
+		store:     s.store,
+		dir:       s.cfg.CertCacheDir,
+		directory: directory,
+		contact:   s.cfg.ACMEContact,
+		ttl:       s.cfg.challengeTTL(),
+		domains:   certDomains(s.logger, root, strings.ToLower(s.cfg.APIHost)),
+		name:      root,
+	}
+}
+
+// certDomains returns the names to request: the root domain, its wildcard and
+// the API host when the wildcard does not already cover it.
+func certDomains(logger *zap.SugaredLogger, root string, apiHost string) []string {
+	domains := []string{root, "*." + root}
+
+	if apiHost == "" || apiHost == root {
+		return domains
+	}
+
+	if !strings.HasSuffix(apiHost, "."+root) {
+		logger.Warnw("API host is outside the root domain, it will not be covered by the certificate", "host", apiHost)
+
+		return domains
+	}
+
+	if strings.Contains(strings.TrimSuffix(apiHost, "."+root), ".") {
+		domains = append(domains, apiHost)
+	}
+
+	return domains
+}
+
+// challengeNames returns the fully qualified owner names the server must
+// answer DNS-01 challenges for.
+func (c *certManager) challengeNames() map[string]struct{} {
+	names := map[string]struct{}{}
+
+	for _, domain := range c.domains {
+		names[challengePrefix+strings.TrimPrefix(domain, "*.")+"."] = struct{}{}
+	}
+
+	return names
+}
+
+func (c *certManager) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Fall back to any statically configured certificate until one is obtained
+	return c.cert, nil
+}
+
+func (c *certManager) run(ctx context.Context) {
+	if err := c.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		c.logger.Warnw("Failed to load cached certificate", "err", err)
+	}
+
+	for {
+		wait := certCheckInterval
+
+		if c.needsRenewal() {
+			c.logger.Infow("Obtaining certificate", "domains", c.domains)
+
+			if err := c.obtain(ctx); err != nil {
+				c.logger.Errorw("Failed to obtain certificate", "err", err)
+
+				wait = certRetryInterval
+			} else {
+				c.logger.Infow("Certificate obtained", "domains", c.domains)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (c *certManager) needsRenewal() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.cert == nil || time.Until(c.cert.Leaf.NotAfter) < certRenewBefore
+}
+
+func (c *certManager) load() error {
+	certPEM, err := os.ReadFile(filepath.Join(c.dir, c.name+".crt"))
+	if err != nil {
+		return err
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(c.dir, c.name+".key"))
+	if err != nil {
+		return err
+	}
+
+	return c.setCertificate(certPEM, keyPEM)
+}
+
+func (c *certManager) setCertificate(certPEM []byte, keyPEM []byte) error {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+
+	if len(cert.Certificate) == 0 {
+		return errNoCertificate
+	}
+
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.cert = &cert
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *certManager) obtain(ctx context.Context) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+
+	account, err := c.loadAccount()
+	if err != nil {
+		return err
+	}
+
+	config := lego.NewConfig(account)
+	config.CADirURL = c.directory
+	config.Certificate.KeyType = certcrypto.EC256
+
+	client, err := lego.NewClient(config)
+	if err != nil {
+		return err
+	}
+
+	if account.Registration == nil || account.Directory != c.directory {
+		account.Registration, err = client.Registration.Register(registration.RegisterOptions{
+			TermsOfServiceAgreed: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		account.Directory = c.directory
+
+		if err := c.saveAccount(account); err != nil {
+			return err
+		}
+	}
+
+	provider := &zoneChallengeProvider{
+		ctx:    ctx,
+		store:  c.store,
+		ttl:    c.ttl,
+		values: map[string][]string{},
+	}
+
+	// The store is the source of truth for the zone, so there is nothing to
+	// wait for once the values have been written
+	err = client.Challenge.SetDNS01Provider(provider, dns01.WrapPreCheck(provider.preCheck))
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: c.domains,
+		Bundle:  true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(c.dir, c.name+".key"), res.PrivateKey, 0o600); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(c.dir, c.name+".crt"), res.Certificate, 0o600); err != nil {
+		return err
+	}
+
+	return c.setCertificate(res.Certificate, res.PrivateKey)
+}
+
+func (c *certManager) loadAccount() (*certAccount, error) {
+	account := &certAccount{
+		Email: c.contact,
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(c.dir, accountKeyFile))
+	if errors.Is(err, os.ErrNotExist) {
+		account.key, err = certcrypto.GeneratePrivateKey(certcrypto.EC256)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPEM = certcrypto.PEMEncode(account.key)
+
+		if err := os.WriteFile(filepath.Join(c.dir, accountKeyFile), keyPEM, 0o600); err != nil {
+			return nil, err
+		}
+
+		return account, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	account.key, err = certcrypto.ParsePEMPrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, accountFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return account, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (c *certManager) saveAccount(account *certAccount) error {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(c.dir, accountFile), data, 0o600)
+}
+
+// zoneChallengeProvider publishes DNS-01 values into the store. The root
+// domain and its wildcard share an owner name, so values are accumulated.
+type zoneChallengeProvider struct {
+	ctx    context.Context
+	store  Store
+	ttl    time.Duration
+	mu     sync.Mutex
+	values map[string][]string
+}
+
+func (p *zoneChallengeProvider) Present(domain, _, keyAuth string) error {
+	info := dns01.GetChallengeInfo(domain, keyAuth)
+	name := strings.ToLower(info.FQDN)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values := make([]string, 0, len(p.values[name])+1)
+	values = append(values, p.values[name]...)
+	values = append(values, info.Value)
+
+	p.values[name] = values
+
+	return p.store.SetZoneChallengeTokens(p.ctx, name, values, p.ttl)
+}
+
+func (p *zoneChallengeProvider) CleanUp(domain, _, keyAuth string) error {
+	info := dns01.GetChallengeInfo(domain, keyAuth)
+	name := strings.ToLower(info.FQDN)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values := make([]string, 0, len(p.values[name]))
+
+	for _, value := range p.values[name] {
+		if value != info.Value {
+			values = append(values, value)
+		}
+	}
+
+	p.values[name] = values
+
+	return p.store.SetZoneChallengeTokens(p.ctx, name, values, p.ttl)
+}
+
+func (p *zoneChallengeProvider) preCheck(_, fqdn, value string, _ dns01.PreCheckFunc) (bool, error) {
+	values, _, err := p.store.GetZoneChallengeTokens(p.ctx, strings.ToLower(fqdn))
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
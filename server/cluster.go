@@ -0,0 +1,578 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	EventInvalidate = "invalidate"
+	EventHeartbeat  = "heartbeat"
+
+	clusterCacheTTL       = 30 * time.Second
+	clusterCacheMax       = 100000
+	clusterHeartbeat      = 5 * time.Second
+	clusterPeerUnhealthy  = 3 * clusterHeartbeat
+	clusterPeerForgotten  = time.Hour
+	clusterPublishTimeout = 2 * time.Second
+)
+
+type ClusterEvent struct {
+	Node         string    `json:"node"`
+	Type         string    `json:"type"`
+	Keys         []string  `json:"keys,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	CacheEntries int       `json:"cache_entries,omitempty"`
+	Time         time.Time `json:"time"`
+}
+
+// ClusterBus distributes events between the nodes sharing a store.
+type ClusterBus interface {
+	PublishClusterEvent(ctx context.Context, event ClusterEvent) error
+
+	SubscribeClusterEvents(ctx context.Context) <-chan ClusterEvent
+}
+
+type ClusterPeer struct {
+	Node         string    `json:"node"`
+	Version      string    `json:"version"`
+	CacheEntries int       `json:"cache_entries"`
+	LastSeen     time.Time `json:"last_seen"`
+	Healthy      bool      `json:"healthy"`
+}
+
+type ClusterStatus struct {
+	Node         string        `json:"node"`
+	CacheEntries int           `json:"cache_entries"`
+	CacheHits    int64         `json:"cache_hits"`
+	CacheMisses  int64         `json:"cache_misses"`
+	Peers        []ClusterPeer `json:"peers"`
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// CachedStore answers the lookups made while serving DNS from memory. Writes
+// are broadcast to the other nodes so they drop their copies, and entries
+// expire after a bounded time in case an invalidation is missed.
+type CachedStore struct {
+	Store
+	logger  *zap.SugaredLogger
+	bus     ClusterBus
+	node    string
+	ttl     time.Duration
+	hits    atomic.Int64
+	misses  atomic.Int64
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	peers   map[string]ClusterPeer
+	// gen is bumped by every invalidation, so loads that raced one are not
+	// stored
+	gen uint64
+}
+
+func NewCachedStore(logger *zap.SugaredLogger, cfg Config, store Store, bus ClusterBus) *CachedStore {
+	node := cfg.NodeName
+	if node == "" {
+		node, _ = os.Hostname()
+	}
+
+	if node == "" {
+		node = uuid.NewString()
+	}
+
+	ttl := cfg.ClusterCacheTTL
+	if ttl <= 0 {
+		ttl = clusterCacheTTL
+	}
+
+	return &CachedStore{
+		Store:   store,
+		logger:  logger,
+		bus:     bus,
+		node:    node,
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+		peers:   map[string]ClusterPeer{},
+	}
+}
+
+func (s *CachedStore) Run(ctx context.Context) {
+	events := s.bus.SubscribeClusterEvents(ctx)
+
+	ticker := time.NewTicker(clusterHeartbeat)
+	defer ticker.Stop()
+
+	s.heartbeat(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.heartbeat(ctx)
+			s.prune()
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return
+				}
+
+				s.logger.Warnw("Cluster subscription closed, resubscribing")
+
+				s.flush()
+
+				events = s.bus.SubscribeClusterEvents(ctx)
+
+				continue
+			}
+
+			s.handleEvent(event)
+		}
+	}
+}
+
+func (s *CachedStore) Status() ClusterStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := ClusterStatus{
+		Node:         s.node,
+		CacheEntries: len(s.entries),
+		CacheHits:    s.hits.Load(),
+		CacheMisses:  s.misses.Load(),
+		Peers:        make([]ClusterPeer, 0, len(s.peers)),
+	}
+
+	for _, peer := range s.peers {
+		peer.Healthy = time.Since(peer.LastSeen) < clusterPeerUnhealthy
+		status.Peers = append(status.Peers, peer)
+	}
+
+	sort.Slice(status.Peers, func(i, j int) bool {
+		return status.Peers[i].Node < status.Peers[j].Node
+	})
+
+	return status
+}
+
+func (s *CachedStore) handleEvent(event ClusterEvent) {
+	if event.Node == s.node {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch event.Type {
+	case EventInvalidate:
+		s.gen++
+
+		for _, key := range event.Keys {
+			delete(s.entries, key)
+		}
+	case EventHeartbeat:
+		s.peers[event.Node] = ClusterPeer{
+			Node:         event.Node,
+			Version:      event.Version,
+			CacheEntries: event.CacheEntries,
+			LastSeen:     time.Now(),
+		}
+	}
+}
+
+func (s *CachedStore) heartbeat(ctx context.Context) {
+	s.mu.Lock()
+	entries := len(s.entries)
+	s.mu.Unlock()
+
+	s.publish(ctx, ClusterEvent{
+		Type:         EventHeartbeat,
+		Version:      Version,
+		CacheEntries: entries,
+	})
+}
+
+func (s *CachedStore) prune() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	for key, entry := range s.entries {
+		if now.After(entry.expires) {
+			delete(s.entries, key)
+		}
+	}
+
+	for node, peer := range s.peers {
+		if now.Sub(peer.LastSeen) > clusterPeerForgotten {
+			delete(s.peers, node)
+		}
+	}
+}
+
+func (s *CachedStore) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gen++
+	s.entries = map[string]cacheEntry{}
+}
+
+func (s *CachedStore) publish(ctx context.Context, event ClusterEvent) {
+	event.Node = s.node
+	event.Time = time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, clusterPublishTimeout)
+	defer cancel()
+
+	if err := s.bus.PublishClusterEvent(ctx, event); err != nil {
+		s.logger.Warnw("Failed to publish cluster event", "type", event.Type, "err", err)
+	}
+}
+
+func (s *CachedStore) invalidate(ctx context.Context, keys ...string) {
+	s.mu.Lock()
+
+	s.gen++
+
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+
+	s.mu.Unlock()
+
+	s.publish(ctx, ClusterEvent{
+		Type: EventInvalidate,
+		Keys: keys,
+	})
+}
+
+func cachedLoad[T any](s *CachedStore, key string, load func() (T, error)) (T, error) {
+	return cachedLoadUntil(s, key, func() (T, time.Time, error) {
+		value, err := load()
+
+		return value, time.Time{}, err
+	})
+}
+
+// cachedLoadUntil caches values that expire in the store, so that they are not
+// served past the expiry returned by load.
+func cachedLoadUntil[T any](s *CachedStore, key string, load func() (T, time.Time, error)) (T, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[key]
+	gen := s.gen
+	s.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		s.hits.Add(1)
+
+		return entry.value.(T), nil
+	}
+
+	s.misses.Add(1)
+
+	value, until, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The value may predate a write made during the load
+	if s.gen != gen {
+		return value, nil
+	}
+
+	if len(s.entries) >= clusterCacheMax {
+		s.entries = map[string]cacheEntry{}
+	}
+
+	expires := time.Now().Add(s.ttl)
+	if !until.IsZero() && until.Before(expires) {
+		expires = until
+	}
+
+	s.entries[key] = cacheEntry{
+		value:   value,
+		expires: expires,
+	}
+
+	return value, nil
+}
+
+type cachedTokens struct {
+	tokens  []string
+	expires time.Time
+}
+
+func acmeCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("acme:%s", id)
+}
+
+func zoneCacheKey(name string) string {
+	return fmt.Sprintf("zone:%s", name)
+}
+
+func labelCacheKey(label string) string {
+	return fmt.Sprintf("label:%s", label)
+}
+
+func delegationCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("delegation:%s", id)
+}
+
+func policyCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("policy:%s", id)
+}
+
+func serviceCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("service:%s", id)
+}
+
+func caaCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("caa:%s", id)
+}
+
+func webhookCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("webhook:%s", id)
+}
+
+func blockCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("block:%s", id)
+}
+
+func revokedCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("revoked:%s", id)
+}
+
+const cidrsCacheKey = "cidrs"
+
+func (s *CachedStore) SetACMEChallengeTokens(ctx context.Context, id uuid.UUID, tokens []string, ttl time.Duration) error {
+	if err := s.Store.SetACMEChallengeTokens(ctx, id, tokens, ttl); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, acmeCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetACMEChallengeTokens(ctx context.Context, id uuid.UUID) ([]string, time.Time, error) {
+	res, err := cachedLoadUntil(s, acmeCacheKey(id), func() (cachedTokens, time.Time, error) {
+		tokens, expires, err := s.Store.GetACMEChallengeTokens(ctx, id)
+
+		return cachedTokens{tokens: tokens, expires: expires}, expires, err
+	})
+
+	return res.tokens, res.expires, err
+}
+
+func (s *CachedStore) SetZoneChallengeTokens(ctx context.Context, name string, tokens []string, ttl time.Duration) error {
+	if err := s.Store.SetZoneChallengeTokens(ctx, name, tokens, ttl); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, zoneCacheKey(name))
+
+	return nil
+}
+
+func (s *CachedStore) GetZoneChallengeTokens(ctx context.Context, name string) ([]string, time.Time, error) {
+	res, err := cachedLoadUntil(s, zoneCacheKey(name), func() (cachedTokens, time.Time, error) {
+		tokens, expires, err := s.Store.GetZoneChallengeTokens(ctx, name)
+
+		return cachedTokens{tokens: tokens, expires: expires}, expires, err
+	})
+
+	return res.tokens, res.expires, err
+}
+
+func (s *CachedStore) ClaimSubdomainLabel(ctx context.Context, label string, id uuid.UUID) (bool, error) {
+	ok, err := s.Store.ClaimSubdomainLabel(ctx, label, id)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	s.invalidate(ctx, labelCacheKey(label))
+
+	return true, nil
+}
+
+func (s *CachedStore) ClaimVanityLabel(
+	ctx context.Context,
+	label string,
+	id uuid.UUID,
+	ttl time.Duration,
+) (bool, string, error) {
+	ok, released, err := s.Store.ClaimVanityLabel(ctx, label, id, ttl)
+	if err != nil || !ok {
+		return ok, released, err
+	}
+
+	keys := []string{labelCacheKey(label)}
+
+	if released != "" {
+		keys = append(keys, labelCacheKey(released))
+	}
+
+	s.invalidate(ctx, keys...)
+
+	return true, released, nil
+}
+
+func (s *CachedStore) GetSubdomainLabel(ctx context.Context, label string) (uuid.UUID, error) {
+	return cachedLoad(s, labelCacheKey(label), func() (uuid.UUID, error) {
+		return s.Store.GetSubdomainLabel(ctx, label)
+	})
+}
+
+func (s *CachedStore) SetDelegation(ctx context.Context, id uuid.UUID, delegation *Delegation) error {
+	if err := s.Store.SetDelegation(ctx, id, delegation); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, delegationCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetDelegation(ctx context.Context, id uuid.UUID) (*Delegation, error) {
+	return cachedLoad(s, delegationCacheKey(id), func() (*Delegation, error) {
+		return s.Store.GetDelegation(ctx, id)
+	})
+}
+
+func (s *CachedStore) SetAddressPolicy(ctx context.Context, id uuid.UUID, classes []string) error {
+	if err := s.Store.SetAddressPolicy(ctx, id, classes); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, policyCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetAddressPolicy(ctx context.Context, id uuid.UUID) ([]string, error) {
+	return cachedLoad(s, policyCacheKey(id), func() ([]string, error) {
+		return s.Store.GetAddressPolicy(ctx, id)
+	})
+}
+
+func (s *CachedStore) SetServiceHints(ctx context.Context, id uuid.UUID, hints *ServiceHints) error {
+	if err := s.Store.SetServiceHints(ctx, id, hints); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, serviceCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetServiceHints(ctx context.Context, id uuid.UUID) (*ServiceHints, error) {
+	return cachedLoad(s, serviceCacheKey(id), func() (*ServiceHints, error) {
+		return s.Store.GetServiceHints(ctx, id)
+	})
+}
+
+func (s *CachedStore) SetCAARecords(ctx context.Context, id uuid.UUID, records []CAARecord) error {
+	if err := s.Store.SetCAARecords(ctx, id, records); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, caaCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetCAARecords(ctx context.Context, id uuid.UUID) ([]CAARecord, error) {
+	return cachedLoad(s, caaCacheKey(id), func() ([]CAARecord, error) {
+		return s.Store.GetCAARecords(ctx, id)
+	})
+}
+
+func (s *CachedStore) SetWebhook(ctx context.Context, id uuid.UUID, hook *Webhook) error {
+	if err := s.Store.SetWebhook(ctx, id, hook); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, webhookCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetWebhook(ctx context.Context, id uuid.UUID) (*Webhook, error) {
+	return cachedLoad(s, webhookCacheKey(id), func() (*Webhook, error) {
+		return s.Store.GetWebhook(ctx, id)
+	})
+}
+
+func (s *CachedStore) BlockSubdomain(ctx context.Context, block SubdomainBlock) error {
+	if err := s.Store.BlockSubdomain(ctx, block); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, blockCacheKey(block.ID))
+
+	return nil
+}
+
+func (s *CachedStore) UnblockSubdomain(ctx context.Context, id uuid.UUID) error {
+	if err := s.Store.UnblockSubdomain(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, blockCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetSubdomainBlock(ctx context.Context, id uuid.UUID) (*SubdomainBlock, error) {
+	return cachedLoad(s, blockCacheKey(id), func() (*SubdomainBlock, error) {
+		return s.Store.GetSubdomainBlock(ctx, id)
+	})
+}
+
+func (s *CachedStore) RevokeSubdomain(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if err := s.Store.RevokeSubdomain(ctx, id, at); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, revokedCacheKey(id))
+
+	return nil
+}
+
+func (s *CachedStore) GetSubdomainRevocation(ctx context.Context, id uuid.UUID) (*time.Time, error) {
+	return cachedLoad(s, revokedCacheKey(id), func() (*time.Time, error) {
+		return s.Store.GetSubdomainRevocation(ctx, id)
+	})
+}
+
+func (s *CachedStore) SetForbiddenCIDRs(ctx context.Context, cidrs []string) error {
+	if err := s.Store.SetForbiddenCIDRs(ctx, cidrs); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, cidrsCacheKey)
+
+	return nil
+}
+
+func (s *CachedStore) GetForbiddenCIDRs(ctx context.Context) ([]string, error) {
+	return cachedLoad(s, cidrsCacheKey, func() ([]string, error) {
+		return s.Store.GetForbiddenCIDRs(ctx)
+	})
+}
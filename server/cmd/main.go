@@ -1,7 +1,13 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/csnewman/dyndirect/server"
+	llog "github.com/go-acme/lego/v4/log"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
@@ -15,6 +21,9 @@ func main() {
 	logger := rawLogger.Sugar()
 	logger.Infow("DynDirect Server")
 
+	// lego logs through a global logger, so it is routed once for the process
+	llog.Logger = zap.NewStdLog(rawLogger)
+
 	var cfg server.Config
 
 	viper.SetConfigFile("config.yml")
@@ -28,10 +37,14 @@ func main() {
 		logger.Fatalw("Config error", "err", err)
 	}
 
+	if err := cfg.Validate(); err != nil {
+		logger.Fatalw("Config error", "err", err)
+	}
+
 	var store server.Store
 
 	if cfg.Store == "mem" {
-		mem, err := server.NewMemStore(logger)
+		mem, err := server.NewMemStore(logger, cfg)
 		if err != nil {
 			logger.Fatal(err)
 		}
@@ -40,13 +53,55 @@ func main() {
 
 		store = mem
 	} else if cfg.Store == "redis" {
-		store = server.NewRedisStore(cfg)
+		redisStore, err := server.NewRedisStore(logger, cfg)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		store = redisStore
+
+		if cfg.ClusterEnabled {
+			cached := server.NewCachedStore(logger, cfg, redisStore, redisStore)
+
+			go cached.Run(context.Background())
+
+			store = cached
+		}
 	} else {
 		logger.Fatalw("Invalid store provided", "store", cfg.Store)
 	}
 
 	s := server.New(logger, cfg, store)
 
+	go func() {
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+
+		for range reload {
+			var cfg server.Config
+
+			if err := viper.ReadInConfig(); err != nil {
+				logger.Errorw("Config reload error", "err", err)
+
+				continue
+			}
+
+			if err := viper.Unmarshal(&cfg); err != nil {
+				logger.Errorw("Config reload error", "err", err)
+
+				continue
+			}
+
+			if err := cfg.Validate(); err != nil {
+				logger.Errorw("Config reload error", "err", err)
+
+				continue
+			}
+
+			s.Reload(cfg)
+		}
+	}()
+
 	if err := s.Start(); err != nil {
 		logger.Fatal(err)
 	}
@@ -1,24 +1,200 @@
 package server
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultChallengeTTL        = time.Hour
+	defaultChallengeMaxEntries = 1000000
+	defaultStoreSweepInterval  = 30 * time.Second
+	defaultAccountSubdomains   = 1000
+	defaultStatsFile           = "cache/stats.json"
+	defaultLabelTTL            = 90 * 24 * time.Hour
+	defaultAbuseReportsMax     = 10000
+	defaultAbuseRetention      = 30 * 24 * time.Hour
+	defaultAbuseReportRate     = 10
+	defaultAccountCreateRate   = 5
+)
+
+var ErrInvalidConfig = errors.New("invalid config")
+
 type Config struct {
-	RootDomain     string                  `mapstructure:"root_domain"`
-	APIHost        string                  `mapstructure:"api_host"`
-	APIListenHTTP  string                  `mapstructure:"api_listen_http"`
-	APIListenHTTPS string                  `mapstructure:"api_listen_https"`
-	APIBehindProxy bool                    `mapstructure:"api_behind_proxy"`
-	CertFile       string                  `mapstructure:"tls_cert"`
-	KeyFile        string                  `mapstructure:"tls_key"`
-	ACMEEnabled    bool                    `mapstructure:"acme_enabled"`
-	ACMEContact    string                  `mapstructure:"acme_contact"`
-	StaticRecords  map[string]StaticRecord `mapstructure:"static_records"`
-	TokenKey       string                  `mapstructure:"token_key"`
-	Store          string                  `mapstructure:"store"`
-	RedisAddr      string                  `mapstructure:"redis_addr"`
-	RedisUser      string                  `mapstructure:"redis_user"`
-	RedisPass      string                  `mapstructure:"redis_pass"`
-	RedisDB        int                     `mapstructure:"redis_db"`
+	RootDomain            string                  `mapstructure:"root_domain"`
+	APIHost               string                  `mapstructure:"api_host"`
+	APIListenHTTP         string                  `mapstructure:"api_listen_http"`
+	APIListenHTTPS        string                  `mapstructure:"api_listen_https"`
+	APIBehindProxy        bool                    `mapstructure:"api_behind_proxy"`
+	CertFile              string                  `mapstructure:"tls_cert"`
+	KeyFile               string                  `mapstructure:"tls_key"`
+	ACMEEnabled           bool                    `mapstructure:"acme_enabled"`
+	DoHEnabled            bool                    `mapstructure:"doh_enabled"`
+	DoTListen             string                  `mapstructure:"dot_listen"`
+	ACMEContact           string                  `mapstructure:"acme_contact"`
+	ACMEChallenge         string                  `mapstructure:"acme_challenge"`
+	ACMEDirectory         string                  `mapstructure:"acme_directory"`
+	CertCacheDir          string                  `mapstructure:"cert_cache_dir"`
+	StaticRecords         map[string]StaticRecord `mapstructure:"static_records"`
+	Nameservers           []string                `mapstructure:"nameservers"`
+	SOA                   SOAConfig               `mapstructure:"soa"`
+	TSIGKeys              []TSIGKey               `mapstructure:"tsig_keys"`
+	Secondaries           []Secondary             `mapstructure:"secondaries"`
+	TokenKey              string                  `mapstructure:"token_key"`
+	IDScheme              string                  `mapstructure:"id_scheme"`
+	ReservedLabels        []string                `mapstructure:"reserved_labels"`
+	AdminListen           string                  `mapstructure:"admin_listen"`
+	AdminToken            string                  `mapstructure:"admin_token"`
+	ForbiddenCIDRs        []string                `mapstructure:"forbidden_cidrs"`
+	AddressClasses        []string                `mapstructure:"address_classes"`
+	ChallengeTTL          time.Duration           `mapstructure:"challenge_ttl"`
+	ChallengeMaxEntries   int                     `mapstructure:"challenge_max_entries"`
+	StoreSweepInterval    time.Duration           `mapstructure:"store_sweep_interval"`
+	Store                 string                  `mapstructure:"store"`
+	RedisAddr             string                  `mapstructure:"redis_addr"`
+	RedisUser             string                  `mapstructure:"redis_user"`
+	RedisPass             string                  `mapstructure:"redis_pass"`
+	RedisDB               int                     `mapstructure:"redis_db"`
+	RedisAddrs            []string                `mapstructure:"redis_addrs"`
+	RedisMasterName       string                  `mapstructure:"redis_master_name"`
+	RedisSentinelUser     string                  `mapstructure:"redis_sentinel_user"`
+	RedisSentinelPass     string                  `mapstructure:"redis_sentinel_pass"`
+	RedisCluster          bool                    `mapstructure:"redis_cluster"`
+	RedisTLS              bool                    `mapstructure:"redis_tls"`
+	RedisTLSCA            string                  `mapstructure:"redis_tls_ca"`
+	RedisTLSServerName    string                  `mapstructure:"redis_tls_server_name"`
+	RedisKeyPrefix        string                  `mapstructure:"redis_key_prefix"`
+	RedisBreakerThreshold int                     `mapstructure:"redis_breaker_threshold"`
+	RedisBreakerCooldown  time.Duration           `mapstructure:"redis_breaker_cooldown"`
+	ClusterEnabled        bool                    `mapstructure:"cluster_enabled"`
+	NodeName              string                  `mapstructure:"node_name"`
+	ClusterCacheTTL       time.Duration           `mapstructure:"cluster_cache_ttl"`
+	WebhookAllowPrivate   bool                    `mapstructure:"webhook_allow_private"`
+	AccountMaxSubdomains  int                     `mapstructure:"account_max_subdomains"`
+	StatsFile             string                  `mapstructure:"stats_file"`
+	LabelTTL              time.Duration           `mapstructure:"label_ttl"`
+	AbuseReportsMax       int                     `mapstructure:"abuse_reports_max"`
+	AbuseRetention        time.Duration           `mapstructure:"abuse_retention"`
+	AbuseReportRate       int                     `mapstructure:"abuse_report_rate"`
+	AccountCreateRate     int                     `mapstructure:"account_create_rate"`
 }
 
 type StaticRecord struct {
 	A []string
 }
+
+type SOAConfig struct {
+	MName   string `mapstructure:"mname"`
+	RName   string `mapstructure:"rname"`
+	Refresh uint32 `mapstructure:"refresh"`
+	Retry   uint32 `mapstructure:"retry"`
+	Expire  uint32 `mapstructure:"expire"`
+	Minimum uint32 `mapstructure:"minimum"`
+}
+
+type TSIGKey struct {
+	Name      string `mapstructure:"name"`
+	Algorithm string `mapstructure:"algorithm"`
+	Secret    string `mapstructure:"secret"`
+}
+
+type Secondary struct {
+	Address string `mapstructure:"address"`
+	Notify  string `mapstructure:"notify"`
+	Key     string `mapstructure:"key"`
+}
+
+func (c Config) challengeTTL() time.Duration {
+	if c.ChallengeTTL <= 0 {
+		return defaultChallengeTTL
+	}
+
+	return c.ChallengeTTL
+}
+
+func (c Config) challengeMaxEntries() int {
+	if c.ChallengeMaxEntries <= 0 {
+		return defaultChallengeMaxEntries
+	}
+
+	return c.ChallengeMaxEntries
+}
+
+func (c Config) storeSweepInterval() time.Duration {
+	if c.StoreSweepInterval <= 0 {
+		return defaultStoreSweepInterval
+	}
+
+	return c.StoreSweepInterval
+}
+
+func (c Config) accountMaxSubdomains() int {
+	if c.AccountMaxSubdomains <= 0 {
+		return defaultAccountSubdomains
+	}
+
+	return c.AccountMaxSubdomains
+}
+
+func (c Config) statsFile() string {
+	if c.StatsFile == "" {
+		return defaultStatsFile
+	}
+
+	return c.StatsFile
+}
+
+func (c Config) labelTTL() time.Duration {
+	if c.LabelTTL <= 0 {
+		return defaultLabelTTL
+	}
+
+	return c.LabelTTL
+}
+
+func (c Config) abuseReportsMax() int {
+	if c.AbuseReportsMax <= 0 {
+		return defaultAbuseReportsMax
+	}
+
+	return c.AbuseReportsMax
+}
+
+func (c Config) abuseRetention() time.Duration {
+	if c.AbuseRetention <= 0 {
+		return defaultAbuseRetention
+	}
+
+	return c.AbuseRetention
+}
+
+// abuseReportRate is the number of reports accepted per IP per hour.
+func (c Config) abuseReportRate() int {
+	if c.AbuseReportRate <= 0 {
+		return defaultAbuseReportRate
+	}
+
+	return c.AbuseReportRate
+}
+
+// accountCreateRate is the number of accounts created per IP per hour.
+func (c Config) accountCreateRate() int {
+	if c.AccountCreateRate <= 0 {
+		return defaultAccountCreateRate
+	}
+
+	return c.AccountCreateRate
+}
+
+// Validate reports settings that would otherwise only fail once requests are
+// served.
+func (c Config) Validate() error {
+	switch c.IDScheme {
+	case "", SchemeUUID, SchemeShort:
+	default:
+		return fmt.Errorf("%w: unknown id_scheme %q", ErrInvalidConfig, c.IDScheme)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"encoding/hex"
+	"net"
+	"strings"
+
+	v1 "github.com/csnewman/dyndirect/server/internal/v1"
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+const delegationTTL = 300
+
+type Delegation struct {
+	Nameservers []string         `json:"nameservers"`
+	Glue        []DelegationGlue `json:"glue,omitempty"`
+	DS          []DelegationDS   `json:"ds,omitempty"`
+}
+
+type DelegationGlue struct {
+	Name string `json:"name"`
+	IP   net.IP `json:"ip"`
+}
+
+type DelegationDS struct {
+	KeyTag     uint16 `json:"key_tag"` //nolint:tagliatelle
+	Algorithm  uint8  `json:"algorithm"`
+	DigestType uint8  `json:"digest_type"` //nolint:tagliatelle
+	Digest     string `json:"digest"`
+}
+
+func parseDelegation(req *v1.SubdomainDelegationRequest, rootDomain string) (*Delegation, error) {
+	if len(req.Nameservers) == 0 {
+		return nil, nil
+	}
+
+	d := &Delegation{}
+	nameservers := map[string]struct{}{}
+
+	for _, ns := range req.Nameservers {
+		ns = strings.ToLower(dns.Fqdn(ns))
+
+		if _, ok := dns.IsDomainName(ns); !ok || dns.CountLabel(ns) < 2 {
+			return nil, errors.Errorf("nameserver '%s' is not a fully qualified domain name", ns)
+		}
+
+		nameservers[ns] = struct{}{}
+		d.Nameservers = append(d.Nameservers, ns)
+	}
+
+	if req.Glue != nil {
+		for _, glue := range *req.Glue {
+			name := strings.ToLower(dns.Fqdn(glue.Name))
+
+			if _, ok := nameservers[name]; !ok {
+				return nil, errors.Errorf("glue name '%s' is not a delegated nameserver", name)
+			}
+
+			if name == rootDomain || !dns.IsSubDomain(rootDomain, name) {
+				return nil, errors.Errorf("glue name '%s' is not within '%s'", name, rootDomain)
+			}
+
+			ip := net.ParseIP(glue.Ip)
+			if ip == nil {
+				return nil, errors.Errorf("glue address '%s' is not a valid IP address", glue.Ip)
+			}
+
+			d.Glue = append(d.Glue, DelegationGlue{
+				Name: name,
+				IP:   ip,
+			})
+		}
+	}
+
+	if req.Ds != nil {
+		for _, ds := range *req.Ds {
+			if _, err := hex.DecodeString(ds.Digest); err != nil || ds.Digest == "" {
+				return nil, errors.Errorf("DS digest '%s' is not valid hex", ds.Digest)
+			}
+
+			d.DS = append(d.DS, DelegationDS{
+				KeyTag:     uint16(ds.KeyTag),
+				Algorithm:  uint8(ds.Algorithm),
+				DigestType: uint8(ds.DigestType),
+				Digest:     strings.ToUpper(ds.Digest),
+			})
+		}
+	}
+
+	return d, nil
+}
+
+// glueLabel returns the label directly below rootDomain of a glue name, which
+// identifies the subdomain the name belongs to.
+func glueLabel(name string, rootDomain string) string {
+	parts := strings.Split(strings.TrimSuffix(name, "."+rootDomain), ".")
+
+	return parts[len(parts)-1]
+}
+
+func (d *Delegation) writeReferral(m *dns.Msg, zone string) {
+	m.Authoritative = false
+
+	for _, ns := range d.Nameservers {
+		m.Ns = append(m.Ns, &dns.NS{
+			Hdr: dns.RR_Header{Name: zone, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: delegationTTL},
+			Ns:  ns,
+		})
+	}
+
+	m.Ns = append(m.Ns, d.dsRecords(zone)...)
+
+	for _, glue := range d.Glue {
+		// Glue outside the referred zone would let the owner answer for names
+		// they do not hold, such as a vanity label they have since released
+		if !dns.IsSubDomain(zone, glue.Name) {
+			continue
+		}
+
+		if v4 := glue.IP.To4(); v4 != nil {
+			m.Extra = append(m.Extra, &dns.A{
+				Hdr: dns.RR_Header{Name: glue.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: delegationTTL},
+				A:   v4,
+			})
+
+			continue
+		}
+
+		m.Extra = append(m.Extra, &dns.AAAA{
+			Hdr:  dns.RR_Header{Name: glue.Name, Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: delegationTTL},
+			AAAA: glue.IP.To16(),
+		})
+	}
+}
+
+func (d *Delegation) dsRecords(zone string) []dns.RR {
+	records := make([]dns.RR, 0, len(d.DS))
+
+	for _, ds := range d.DS {
+		records = append(records, &dns.DS{
+			Hdr:        dns.RR_Header{Name: zone, Rrtype: dns.TypeDS, Class: dns.ClassINET, Ttl: delegationTTL},
+			KeyTag:     ds.KeyTag,
+			Algorithm:  ds.Algorithm,
+			DigestType: ds.DigestType,
+			Digest:     ds.Digest,
+		})
+	}
+
+	return records
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "github.com/csnewman/dyndirect/server/internal/v1"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	FeatureShortIDs      = "short-ids"
+	FeatureVanityLabels  = "vanity-labels"
+	FeatureDelegation    = "delegation"
+	FeatureAddressPolicy = "address-policy"
+	FeatureAbuseReport   = "abuse-report"
+	FeatureServiceHints  = "service-hints"
+	FeatureCAA           = "caa"
+	FeatureWebhooks      = "webhooks"
+	FeatureAccounts      = "accounts"
+	FeatureTokenRotation = "token-rotation"
+	FeatureDoH           = "doh"
+	FeatureDoT           = "dot"
+
+	maxACMEValues  = 10
+	maxNameservers = 8
+)
+
+func (s *Server) buildDiscovery(scheme string) v1.DiscoveryResponse {
+	base := fmt.Sprintf("%s://%s/", scheme, s.cfg.APIHost)
+
+	features := []string{
+		FeatureShortIDs,
+		FeatureVanityLabels,
+		FeatureDelegation,
+		FeatureAddressPolicy,
+		FeatureAbuseReport,
+		FeatureServiceHints,
+		FeatureCAA,
+		FeatureWebhooks,
+		FeatureAccounts,
+		FeatureTokenRotation,
+	}
+
+	if s.cfg.DoHEnabled {
+		features = append(features, FeatureDoH)
+	}
+
+	if s.cfg.DoTListen != "" {
+		features = append(features, FeatureDoT)
+	}
+
+	return v1.DiscoveryResponse{
+		ServerVersion: Version,
+		Versions: []v1.DiscoveryVersion{
+			{
+				Version: "v1",
+				Url:     base,
+				Spec:    base + "openapi.json",
+			},
+		},
+		Zones:       []string{strings.TrimSuffix(s.cfg.RootDomain, ".")},
+		Features:    features,
+		RecordTypes: []string{"A", "AAAA", "TXT", "NS", "DS", "HTTPS", "SVCB", "CAA"},
+		AuthModes:   []string{"token", "account-key"},
+		IdSchemes:   []v1.SubdomainScheme{v1.Uuid, v1.Short},
+		RateLimits: []v1.DiscoveryRateLimit{
+			{
+				Scope:  "report-abuse",
+				Limit:  s.cfg.abuseReportRate(),
+				Period: int(abuseReportWindow / time.Second),
+			},
+			{
+				Scope:  "create-account",
+				Limit:  s.cfg.accountCreateRate(),
+				Period: int(accountCreateWindow / time.Second),
+			},
+		},
+		Limits: v1.DiscoveryLimits{
+			AcmeValues:        maxACMEValues,
+			Nameservers:       maxNameservers,
+			LabelMinLength:    labelMinLength,
+			LabelMaxLength:    labelMaxLength,
+			ChallengeTtl:      int(s.cfg.challengeTTL() / time.Second),
+			AccountSubdomains: s.cfg.accountMaxSubdomains(),
+		},
+	}
+}
+
+func (s *Server) buildOpenAPIHandler() (http.HandlerFunc, error) {
+	encoded := map[string][]byte{}
+
+	for _, scheme := range []string{"http", "https"} {
+		spec, err := v1.GetSwagger()
+		if err != nil {
+			return nil, err
+		}
+
+		spec.Servers = openapi3.Servers{
+			{URL: fmt.Sprintf("%s://%s", scheme, s.cfg.APIHost)},
+		}
+
+		encoded[scheme], err = json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(encoded[requestScheme(r, s.cfg.APIBehindProxy)])
+	}, nil
+}
+
+// requestScheme is the scheme the client used to reach the API. Proxies are
+// assumed to terminate TLS unless they report otherwise.
+func requestScheme(r *http.Request, behindProxy bool) string {
+	if r.TLS != nil {
+		return "https"
+	}
+
+	if !behindProxy {
+		return "http"
+	}
+
+	if proto := strings.ToLower(r.Header.Get("X-Forwarded-Proto")); proto == "http" {
+		return "http"
+	}
+
+	return "https"
+}
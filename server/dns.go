@@ -23,22 +23,26 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		}
 	}()
 
-	m := new(dns.Msg)
-	m.SetReply(r)
-	m.Compress = true
-	m.Authoritative = true
+	if len(r.Question) == 1 && (r.Question[0].Qtype == dns.TypeAXFR || r.Question[0].Qtype == dns.TypeIXFR) {
+		s.serveTransfer(w, r)
+
+		return
+	}
 
 	ctx, can := context.WithTimeout(context.Background(), time.Second*5)
 	defer can()
 
-	if err := s.handleDNS(ctx, r, m); err != nil {
+	m, err := s.resolveDNS(ctx, r)
+	if err != nil {
 		s.logger.WithOptions(zap.AddStacktrace(zapcore.NewNopCore())).Errorw(
 			"DNS Request Error",
 			"request_id", r.Id,
 			"err", err,
 		)
 
-		return
+		// Let resolvers move on to another node rather than time out
+		m = new(dns.Msg)
+		m.SetRcode(r, dns.RcodeServerFailure)
 	}
 
 	if err := w.WriteMsg(m); err != nil {
@@ -52,9 +56,29 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 }
 
+func (s *Server) resolveDNS(ctx context.Context, r *dns.Msg) (*dns.Msg, error) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+	m.Compress = true
+	m.Authoritative = true
+
+	if err := s.handleDNS(ctx, r, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// handleDNS answers each question from the store. An A or AAAA question for a
+// subdomain costs up to six sequential lookups: the label (skipped for UUID
+// names), revocation, block, delegation, forbidden CIDRs and address policy,
+// with service hints added for HTTPS and SVCB. Against a plain RedisStore each
+// is a round trip; with clustering enabled CachedStore serves them from memory.
 func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 	s.store.IncrementStat(ctx, "dns_questions", int64(len(r.Question)))
 
+	z := s.zone.Load()
+
 	for _, q := range r.Question {
 		s.logger.Infow("DNS Question", "Id", r.Id, "Name", q.Name, "Qtype", q.Qtype, "Qclass", q.Qclass)
 
@@ -64,6 +88,25 @@ func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 
 		lcName := strings.ToLower(q.Name)
 
+		if _, ok := s.zoneChallenges[lcName]; ok && q.Qtype == dns.TypeTXT {
+			s.logger.Infow("DNS Zone ACME Request", "name", q.Name)
+			s.store.IncrementStat(ctx, "dns_zone_acme", 1)
+
+			values, _, err := s.store.GetZoneChallengeTokens(ctx, lcName)
+			if err != nil {
+				return err
+			}
+
+			for _, token := range values {
+				m.Answer = append(m.Answer, &dns.TXT{
+					Hdr: dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: q.Qclass, Ttl: 0},
+					Txt: []string{token},
+				})
+			}
+
+			continue
+		}
+
 		var name string
 
 		if lcName == s.cfg.RootDomain {
@@ -74,7 +117,19 @@ func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 			continue
 		}
 
-		static, hasStatic := s.cfg.StaticRecords[name]
+		if name == "@" && q.Qtype == dns.TypeSOA {
+			m.Answer = append(m.Answer, z.soaRecord(q.Name))
+
+			continue
+		}
+
+		if name == "@" && q.Qtype == dns.TypeNS {
+			m.Answer = append(m.Answer, z.nsRecords(q.Name)...)
+
+			continue
+		}
+
+		static, hasStatic := z.records[name]
 
 		if hasStatic {
 			s.store.IncrementStat(ctx, "dns_static", 1)
@@ -92,22 +147,74 @@ func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 		}
 
 		parts := strings.Split(name, ".")
-		if len(parts) != 2 {
+		label := parts[len(parts)-1]
+
+		id, err := resolveSubdomain(ctx, s.store, label)
+		if err != nil {
+			return err
+		}
+
+		if id == uuid.Nil {
+			continue
+		}
+
+		revoked, err := s.store.GetSubdomainRevocation(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		block, err := s.store.GetSubdomainBlock(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if revoked != nil || block != nil {
+			s.store.IncrementStat(ctx, "dns_blocked", 1)
+
+			m.Rcode = dns.RcodeNameError
+
 			continue
 		}
 
-		id, err := uuid.Parse(parts[1])
+		delegation, err := s.store.GetDelegation(ctx, id)
 		if err != nil {
+			return err
+		}
+
+		if delegation != nil {
+			s.store.IncrementStat(ctx, "dns_delegated", 1)
+
+			zone := label + "." + s.cfg.RootDomain
+
+			if len(parts) == 1 && q.Qtype == dns.TypeDS {
+				m.Answer = append(m.Answer, delegation.dsRecords(zone)...)
+			} else {
+				delegation.writeReferral(m, zone)
+			}
+
 			continue
 		}
 
-		req := parts[0]
+		if len(parts) == 1 && q.Qtype == dns.TypeCAA {
+			s.store.IncrementStat(ctx, "dns_caa", 1)
 
-		if req == "_acme-challenge" && q.Qtype == dns.TypeTXT {
+			records, err := s.store.GetCAARecords(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			m.Answer = append(m.Answer, caaAnswers(q, records)...)
+
+			continue
+		}
+
+		// Challenges for names below the subdomain share its tokens, as the
+		// CA only checks that the expected value is present
+		if len(parts) >= 2 && parts[0] == "_acme-challenge" && q.Qtype == dns.TypeTXT {
 			s.logger.Infow("DNS ACME Request", "name", q.Name, "id", id)
 			s.store.IncrementStat(ctx, "dns_acme", 1)
 
-			values, err := s.store.GetACMEChallengeTokens(ctx, id)
+			values, _, err := s.store.GetACMEChallengeTokens(ctx, id)
 			if err != nil {
 				return err
 			}
@@ -122,6 +229,12 @@ func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 			continue
 		}
 
+		if len(parts) != 2 {
+			continue
+		}
+
+		req := parts[0]
+
 		lastInd := strings.LastIndex(req, "-")
 		if lastInd == -1 {
 			continue
@@ -143,6 +256,14 @@ func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 				continue
 			}
 
+			if allowed, err := s.synthesisAllowed(ctx, id, v4); err != nil {
+				return err
+			} else if !allowed {
+				s.store.IncrementStat(ctx, "dns_forbidden", 1)
+
+				continue
+			}
+
 			m.Answer = append(m.Answer, &dns.A{
 				Hdr: dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: q.Qclass, Ttl: 0},
 				A:   v4,
@@ -164,6 +285,14 @@ func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 				continue
 			}
 
+			if allowed, err := s.synthesisAllowed(ctx, id, v6); err != nil {
+				return err
+			} else if !allowed {
+				s.store.IncrementStat(ctx, "dns_forbidden", 1)
+
+				continue
+			}
+
 			m.Answer = append(m.Answer, &dns.AAAA{
 				Hdr:  dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: q.Qclass, Ttl: 0},
 				AAAA: v6,
@@ -171,6 +300,32 @@ func (s *Server) handleDNS(ctx context.Context, r *dns.Msg, m *dns.Msg) error {
 
 			s.logger.Infow("DNS V6 Request", "name", q.Name, "id", id, "ip", v6)
 
+			continue
+		} else if (reqType == "v4" || reqType == "v6") && (q.Qtype == dns.TypeHTTPS || q.Qtype == dns.TypeSVCB) {
+			s.store.IncrementStat(ctx, "dns_service", 1)
+
+			ip := decodeAddress(reqType, reqValue)
+			if ip == nil {
+				continue
+			}
+
+			if allowed, err := s.synthesisAllowed(ctx, id, ip); err != nil {
+				return err
+			} else if !allowed {
+				s.store.IncrementStat(ctx, "dns_forbidden", 1)
+
+				continue
+			}
+
+			rr, err := s.serviceRecord(ctx, q, id, ip)
+			if err != nil {
+				return err
+			}
+
+			if rr != nil {
+				m.Answer = append(m.Answer, rr)
+			}
+
 			continue
 		}
 	}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+const testRoot = "dyn.test."
+
+var testID = uuid.MustParse("497f6eca-6276-4993-bfeb-53cbbbba6f08")
+
+func newTestStore(t *testing.T) *MemStore {
+	t.Helper()
+
+	store, err := NewMemStore(zap.NewNop().Sugar(), Config{
+		StatsFile: filepath.Join(t.TempDir(), "stats.json"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store
+}
+
+func newTestServer(t *testing.T, cfg Config) (*Server, *MemStore) {
+	t.Helper()
+
+	cfg.RootDomain = testRoot
+	cfg.TokenKey = "test"
+
+	store := newTestStore(t)
+
+	return New(zap.NewNop().Sugar(), cfg, store), store
+}
+
+// rdata drops the owner, class and TTL, leaving what tests compare.
+func rdata(records []dns.RR) []string {
+	res := make([]string, 0, len(records))
+
+	for _, rr := range records {
+		res = append(res, rr.String()[len(rr.Header().String()):])
+	}
+
+	return res
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestHandleDNS(t *testing.T) {
+	ctx := context.Background()
+	sub := testID.String() + "." + testRoot
+
+	tests := []struct {
+		name   string
+		setup  func(t *testing.T, store *MemStore)
+		qname  string
+		qtype  uint16
+		rcode  int
+		answer []string
+		ns     []string
+	}{
+		{
+			name:   "root nameservers",
+			qname:  testRoot,
+			qtype:  dns.TypeNS,
+			answer: []string{"ns1.dyn.test.", "ns2.dyn.test."},
+		},
+		{
+			name:   "static record",
+			qname:  "www." + testRoot,
+			qtype:  dns.TypeA,
+			answer: []string{"192.0.2.1"},
+		},
+		{
+			name:   "outside zone",
+			qname:  "example.com.",
+			qtype:  dns.TypeA,
+			answer: []string{},
+		},
+		{
+			name:   "v4 address",
+			qname:  "8-8-8-8-v4." + sub,
+			qtype:  dns.TypeA,
+			answer: []string{"8.8.8.8"},
+		},
+		{
+			name:   "v6 address",
+			qname:  "2606-4700--1111-v6." + sub,
+			qtype:  dns.TypeAAAA,
+			answer: []string{"2606:4700::1111"},
+		},
+		{
+			name:   "mismatched type",
+			qname:  "8-8-8-8-v4." + sub,
+			qtype:  dns.TypeAAAA,
+			answer: []string{},
+		},
+		{
+			name:   "invalid address",
+			qname:  "8-8-8-v4." + sub,
+			qtype:  dns.TypeA,
+			answer: []string{},
+		},
+		{
+			name: "short label",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if _, err := store.ClaimSubdomainLabel(ctx, "k2v7qmxa", testID); err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  "8-8-8-8-v4.k2v7qmxa." + testRoot,
+			qtype:  dns.TypeA,
+			answer: []string{"8.8.8.8"},
+		},
+		{
+			name:   "unknown label",
+			qname:  "8-8-8-8-v4.k2v7qmxa." + testRoot,
+			qtype:  dns.TypeA,
+			answer: []string{},
+		},
+		{
+			name: "acme challenge",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if err := store.SetACMEChallengeTokens(ctx, testID, []string{"token"}, time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  "_acme-challenge." + sub,
+			qtype:  dns.TypeTXT,
+			answer: []string{`"token"`},
+		},
+		{
+			name: "caa",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				err := store.SetCAARecords(ctx, testID, []CAARecord{{Tag: "issue", Value: "letsencrypt.org"}})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  sub,
+			qtype:  dns.TypeCAA,
+			answer: []string{`0 issue "letsencrypt.org"`},
+		},
+		{
+			name: "address policy",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if err := store.SetAddressPolicy(ctx, testID, []string{ClassPrivate}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  "8-8-8-8-v4." + sub,
+			qtype:  dns.TypeA,
+			answer: []string{},
+		},
+		{
+			name: "forbidden cidr",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if err := store.SetForbiddenCIDRs(ctx, []string{"8.8.8.0/24"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  "8-8-8-8-v4." + sub,
+			qtype:  dns.TypeA,
+			answer: []string{},
+		},
+		{
+			name: "revoked",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if err := store.RevokeSubdomain(ctx, testID, time.Now()); err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  "8-8-8-8-v4." + sub,
+			qtype:  dns.TypeA,
+			rcode:  dns.RcodeNameError,
+			answer: []string{},
+		},
+		{
+			name: "blocked",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if err := store.BlockSubdomain(ctx, SubdomainBlock{ID: testID, Time: time.Now()}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  "8-8-8-8-v4." + sub,
+			qtype:  dns.TypeA,
+			rcode:  dns.RcodeNameError,
+			answer: []string{},
+		},
+		{
+			name: "delegated",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				err := store.SetDelegation(ctx, testID, &Delegation{Nameservers: []string{"ns1.example.com."}})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			qname:  "8-8-8-8-v4." + sub,
+			qtype:  dns.TypeA,
+			answer: []string{},
+			ns:     []string{"ns1.example.com."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, store := newTestServer(t, Config{
+				Nameservers:   []string{"ns1.dyn.test", "ns2.dyn.test"},
+				StaticRecords: map[string]StaticRecord{"www": {A: []string{"192.0.2.1"}}},
+			})
+
+			if tt.setup != nil {
+				tt.setup(t, store)
+			}
+
+			r := new(dns.Msg)
+			r.SetQuestion(tt.qname, tt.qtype)
+
+			m, err := s.resolveDNS(ctx, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.Rcode != tt.rcode {
+				t.Errorf("expected rcode %s, got %s", dns.RcodeToString[tt.rcode], dns.RcodeToString[m.Rcode])
+			}
+
+			if got := rdata(m.Answer); !equalStrings(got, tt.answer) {
+				t.Errorf("expected answers %q, got %q", tt.answer, got)
+			}
+
+			if got := rdata(m.Ns); len(tt.ns) > 0 && !equalStrings(got, tt.ns) {
+				t.Errorf("expected authority %q, got %q", tt.ns, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	dohContentType = "application/dns-message"
+	dohMaxSize     = 65535
+)
+
+func (s *Server) serveDoH(w http.ResponseWriter, r *http.Request) {
+	var raw []byte
+
+	switch r.Method {
+	case http.MethodGet:
+		decoded, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		if err != nil || len(decoded) == 0 {
+			writeResponse(w, r, http.StatusBadRequest, "bad-request", "Invalid dns parameter")
+
+			return
+		}
+
+		raw = decoded
+	case http.MethodPost:
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != dohContentType {
+			writeResponse(w, r, http.StatusUnsupportedMediaType, "bad-request", "Unsupported content type")
+
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, dohMaxSize))
+		if err != nil {
+			writeResponse(w, r, http.StatusBadRequest, "bad-request", "Failed to read body")
+
+			return
+		}
+
+		raw = body
+	}
+
+	req := new(dns.Msg)
+	if err := req.Unpack(raw); err != nil {
+		writeResponse(w, r, http.StatusBadRequest, "bad-request", "Invalid DNS message")
+
+		return
+	}
+
+	s.store.IncrementStat(r.Context(), "doh_requests", 1)
+
+	m, err := s.resolveDNS(r.Context(), req)
+	if err != nil {
+		s.logger.WithOptions(zap.AddStacktrace(zapcore.NewNopCore())).Errorw(
+			"DoH Request Error",
+			"request_id", middleware.GetReqID(r.Context()),
+			"dns_id", req.Id,
+			"err", err,
+		)
+
+		m = new(dns.Msg)
+		m.SetRcode(req, dns.RcodeServerFailure)
+	}
+
+	maxAge, cacheable := dohMaxAge(m)
+
+	packed, err := m.Pack()
+	if err != nil {
+		// Owner supplied records can fail to pack, which only affects that name
+		s.logger.WithOptions(zap.AddStacktrace(zapcore.NewNopCore())).Errorw(
+			"DoH Response Error",
+			"request_id", middleware.GetReqID(r.Context()),
+			"dns_id", req.Id,
+			"err", err,
+		)
+
+		fail := new(dns.Msg)
+		fail.SetRcode(req, dns.RcodeServerFailure)
+
+		cacheable = false
+
+		packed, err = fail.Pack()
+		if err != nil {
+			writeResponse(w, r, http.StatusInternalServerError, "internal-error", "Failed to encode DNS message")
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", dohContentType)
+
+	if cacheable {
+		w.Header().Set("Cache-Control", "max-age="+strconv.FormatUint(uint64(maxAge), 10))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(packed)
+}
+
+// dohMaxAge is the smallest TTL of the answers, or of the authority records
+// of a negative answer, which HTTP caches must not exceed (RFC 8484 5.1).
+func dohMaxAge(m *dns.Msg) (uint32, bool) {
+	records := m.Answer
+	if len(records) == 0 {
+		records = m.Ns
+	}
+
+	if len(records) == 0 {
+		return 0, false
+	}
+
+	maxAge := records[0].Header().Ttl
+
+	for _, rr := range records[1:] {
+		if ttl := rr.Header().Ttl; ttl < maxAge {
+			maxAge = ttl
+		}
+	}
+
+	return maxAge, true
+}
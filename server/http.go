@@ -33,15 +33,42 @@ func (s *Server) buildHTTPRouter() (*chi.Mux, error) {
 	r.Use(s.httpRecoverer)
 	r.Use(middleware.Timeout(5 * time.Second))
 
+	openAPIHandler, err := s.buildOpenAPIHandler()
+	if err != nil {
+		return nil, err
+	}
+
+	r.Get("/openapi.json", openAPIHandler)
+
+	if s.cfg.DoHEnabled {
+		r.Get("/dns-query", s.serveDoH)
+		r.Post("/dns-query", s.serveDoH)
+	}
+
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(
+			w, r, http.StatusNotFound,
+			"not-found",
+			"The requested resource does not exist",
+		)
+	})
+
 	spec, err := v1.GetSwagger()
 	if err != nil {
 		return nil, err
 	}
 
-	r.Use(oapi.OapiRequestValidatorWithOptions(
+	// Validate requests regardless of the host and scheme they were received on
+	spec.Servers = nil
+
+	api := r.With(oapi.OapiRequestValidatorWithOptions(
 		spec,
 		&oapi.Options{
-			Options: openapi3filter.Options{},
+			// Account keys are checked by the handlers, which also accept them
+			// in place of subdomain tokens
+			Options: openapi3filter.Options{
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
 			ErrorHandler: func(w http.ResponseWriter, message string, statusCode int) {
 				v := &v1.ErrorResponse{
 					Error:   "bad-request",
@@ -65,12 +92,37 @@ func (s *Server) buildHTTPRouter() (*chi.Mux, error) {
 
 	tokenHash := sha512.Sum512([]byte(s.cfg.TokenKey))
 
+	idScheme := s.cfg.IDScheme
+	if idScheme == "" {
+		idScheme = SchemeUUID
+	}
+
+	// The API URLs use the scheme of the request, as the server may be
+	// reached directly or through a proxy terminating TLS
+	discovery := map[string]v1.DiscoveryResponse{
+		"http":  s.buildDiscovery("http"),
+		"https": s.buildDiscovery("https"),
+	}
+
 	v1.HandlerWithOptions(
 		v1.NewStrictHandlerWithOptions(
 			&v1API{
-				tokenHash:  tokenHash[:],
-				store:      s.store,
-				rootDomain: strings.TrimSuffix(s.cfg.RootDomain, "."),
+				logger:          s.logger,
+				tokenHash:       tokenHash[:],
+				store:           s.store,
+				rootDomain:      strings.TrimSuffix(s.cfg.RootDomain, "."),
+				idScheme:        idScheme,
+				challengeTTL:    s.cfg.challengeTTL(),
+				zone:            &s.zone,
+				addressClasses:  s.addressClasses,
+				behindProxy:     s.cfg.APIBehindProxy,
+				discovery:       discovery,
+				webhooks:        s.webhooks,
+				webhookPrivate:  s.cfg.WebhookAllowPrivate,
+				maxSubdomains:   s.cfg.accountMaxSubdomains(),
+				labelTTL:        s.cfg.labelTTL(),
+				abuseReportRate: s.cfg.abuseReportRate(),
+				accountRate:     s.cfg.accountCreateRate(),
 			},
 			[]v1.StrictMiddlewareFunc{
 				s.requestMiddleware,
@@ -107,7 +159,7 @@ func (s *Server) buildHTTPRouter() (*chi.Mux, error) {
 			},
 		),
 		v1.ChiServerOptions{
-			BaseRouter: r,
+			BaseRouter: api,
 			ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
 				s.logger.WithOptions(zap.AddStacktrace(zapcore.NewNopCore())).Errorw(
 					"API Request Error",
@@ -128,6 +180,11 @@ func (s *Server) buildHTTPRouter() (*chi.Mux, error) {
 	return r, nil
 }
 
+// HTTPHandler returns the API handler, for serving it on a custom listener.
+func (s *Server) HTTPHandler() (http.Handler, error) {
+	return s.buildHTTPRouter()
+}
+
 func (s *Server) buildHTTPRedirectServer() *http.Server {
 	r := chi.NewRouter()
 
@@ -141,7 +198,7 @@ func (s *Server) buildHTTPRedirectServer() *http.Server {
 	r.Use(s.httpRecoverer)
 	r.Use(middleware.Timeout(5 * time.Second))
 
-	if s.cfg.ACMEEnabled {
+	if s.acm != nil {
 		r.Use(s.acm.HTTPHandler)
 	}
 
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
@@ -21,6 +22,265 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	AccountKeyScopes = "AccountKey.Scopes"
+)
+
+// Defines values for ALPNProtocol.
+const (
+	H2     ALPNProtocol = "h2"
+	H3     ALPNProtocol = "h3"
+	Http11 ALPNProtocol = "http/1.1"
+)
+
+// Defines values for AddressClass.
+const (
+	LinkLocal AddressClass = "link-local"
+	Loopback  AddressClass = "loopback"
+	Private   AddressClass = "private"
+	Public    AddressClass = "public"
+	Ula       AddressClass = "ula"
+)
+
+// Defines values for CAATag.
+const (
+	Iodef     CAATag = "iodef"
+	Issue     CAATag = "issue"
+	Issuewild CAATag = "issuewild"
+)
+
+// Defines values for SubdomainScheme.
+const (
+	Short SubdomainScheme = "short"
+	Uuid  SubdomainScheme = "uuid"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Delivered WebhookDeliveryStatus = "delivered"
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WebhookEvent.
+const (
+	AcmeSet            WebhookEvent = "acme_set"
+	AddressPolicySet   WebhookEvent = "address_policy_set"
+	CaaSet             WebhookEvent = "caa_set"
+	DelegationSet      WebhookEvent = "delegation_set"
+	LabelClaimed       WebhookEvent = "label_claimed"
+	ServiceSet         WebhookEvent = "service_set"
+	SubdomainBlocked   WebhookEvent = "subdomain_blocked"
+	SubdomainRevoked   WebhookEvent = "subdomain_revoked"
+	SubdomainUnblocked WebhookEvent = "subdomain_unblocked"
+	TokenRotated       WebhookEvent = "token_rotated"
+	WebhookSet         WebhookEvent = "webhook_set"
+)
+
+// ALPNProtocol Application protocol.
+type ALPNProtocol string
+
+// AbuseReportRequest Abuse Report Request.
+type AbuseReportRequest struct {
+	// Contact Optional contact details of the reporter.
+	Contact *string `json:"contact,omitempty"`
+
+	// Domain Reported domain.
+	Domain string `json:"domain"`
+
+	// Reason Description of the abuse.
+	Reason string `json:"reason"`
+}
+
+// AbuseReportResponse Abuse Report Response.
+type AbuseReportResponse struct {
+	// Id Report ID.
+	Id openapi_types.UUID `json:"id"`
+}
+
+// AccountResponse Account Response.
+type AccountResponse struct {
+	// Id Account ID.
+	Id openapi_types.UUID `json:"id"`
+
+	// Key Account API key.
+	Key string `json:"key"`
+}
+
+// AccountRevokeRequest Account Revoke Request.
+type AccountRevokeRequest struct {
+	// Ids IDs of the subdomains to revoke.
+	Ids []openapi_types.UUID `json:"ids"`
+}
+
+// AccountRevokeResponse Account Revoke Response.
+type AccountRevokeResponse struct {
+	// Revoked IDs of the subdomains that were revoked.
+	Revoked []openapi_types.UUID `json:"revoked"`
+}
+
+// AccountSubdomain Subdomain owned by an account.
+type AccountSubdomain struct {
+	// Created Time the subdomain was allocated.
+	Created time.Time `json:"created"`
+
+	// Description Description given on allocation.
+	Description *string `json:"description,omitempty"`
+
+	// Domain Allocated domain.
+	Domain string `json:"domain"`
+
+	// Id Subdomain ID.
+	Id openapi_types.UUID `json:"id"`
+
+	// Revoked Time the subdomain was revoked.
+	Revoked *time.Time `json:"revoked,omitempty"`
+}
+
+// AccountSubdomainRequest Account Subdomain Request.
+type AccountSubdomainRequest struct {
+	// Description Optional description of the subdomain.
+	Description *string `json:"description,omitempty"`
+}
+
+// AccountSubdomainsResponse Account Subdomains Response.
+type AccountSubdomainsResponse struct {
+	Subdomains []AccountSubdomain `json:"subdomains"`
+}
+
+// AcmeChallengeResponse ACME Challenge Response.
+type AcmeChallengeResponse struct {
+	// Expires Time the tokens will stop being served.
+	Expires time.Time `json:"expires"`
+
+	// Ttl Effective lifetime of the tokens in seconds.
+	Ttl int `json:"ttl"`
+}
+
+// AddressClass Class of IP address.
+type AddressClass string
+
+// AddressPolicyResponse Address Policy Response.
+type AddressPolicyResponse struct {
+	// Classes Effective address classes that will be synthesized.
+	Classes []AddressClass `json:"classes"`
+}
+
+// CAARecord CAA record.
+type CAARecord struct {
+	// Flag Flags, 128 marks the record as critical.
+	Flag int `json:"flag"`
+
+	// Tag CAA property tag.
+	Tag CAATag `json:"tag"`
+
+	// Value Issuer domain with optional parameters, or an iodef URL.
+	Value string `json:"value"`
+}
+
+// CAATag CAA property tag.
+type CAATag string
+
+// CreateAccountRequest Create Account Request.
+type CreateAccountRequest struct {
+	// Name Optional name of the account.
+	Name *string `json:"name,omitempty"`
+}
+
+// DelegationDS Delegation signer record.
+type DelegationDS struct {
+	Algorithm int `json:"algorithm"`
+
+	// Digest Hex encoded digest.
+	Digest     string `json:"digest"`
+	DigestType int    `json:"digest_type"`
+	KeyTag     int    `json:"key_tag"`
+}
+
+// DelegationGlue Nameserver glue address.
+type DelegationGlue struct {
+	// Ip IPv4 or IPv6 address.
+	Ip string `json:"ip"`
+
+	// Name Nameserver name.
+	Name string `json:"name"`
+}
+
+// DiscoveryLimits Request limits.
+type DiscoveryLimits struct {
+	// AccountSubdomains Maximum subdomains per account, including revoked subdomains.
+	AccountSubdomains int `json:"account_subdomains"`
+
+	// AcmeValues Maximum ACME challenge values per subdomain.
+	AcmeValues int `json:"acme_values"`
+
+	// ChallengeTtl Maximum ACME challenge lifetime in seconds.
+	ChallengeTtl int `json:"challenge_ttl"`
+
+	// LabelMaxLength Maximum vanity label length.
+	LabelMaxLength int `json:"label_max_length"`
+
+	// LabelMinLength Minimum vanity label length.
+	LabelMinLength int `json:"label_min_length"`
+
+	// Nameservers Maximum delegated nameservers per subdomain.
+	Nameservers int `json:"nameservers"`
+}
+
+// DiscoveryRateLimit Rate limit.
+type DiscoveryRateLimit struct {
+	// Limit Number of requests allowed per period.
+	Limit int `json:"limit"`
+
+	// Period Period in seconds.
+	Period int `json:"period"`
+
+	// Scope Scope the limit applies to, such as an operation.
+	Scope string `json:"scope"`
+}
+
+// DiscoveryResponse Discovery Response.
+type DiscoveryResponse struct {
+	// AuthModes Supported authentication modes.
+	AuthModes []string `json:"auth_modes"`
+
+	// Features Optional features supported by the server.
+	Features []string `json:"features"`
+
+	// IdSchemes Supported subdomain ID schemes.
+	IdSchemes []SubdomainScheme `json:"id_schemes"`
+
+	// Limits Request limits.
+	Limits DiscoveryLimits `json:"limits"`
+
+	// RateLimits Rate limits applied by the server.
+	RateLimits []DiscoveryRateLimit `json:"rate_limits"`
+
+	// RecordTypes DNS record types served for subdomains.
+	RecordTypes []string `json:"record_types"`
+
+	// ServerVersion Server Version.
+	ServerVersion string `json:"server_version"`
+
+	// Versions Supported API versions.
+	Versions []DiscoveryVersion `json:"versions"`
+
+	// Zones Zones subdomains are allocated from.
+	Zones []string `json:"zones"`
+}
+
+// DiscoveryVersion API version.
+type DiscoveryVersion struct {
+	// Spec URL of the OpenAPI document.
+	Spec string `json:"spec"`
+
+	// Url Base URL of the API.
+	Url string `json:"url"`
+
+	// Version API version identifier.
+	Version string `json:"version"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -35,7 +295,7 @@ type NewSubdomainResponse struct {
 	// Id Subdomain ID.
 	Id openapi_types.UUID `json:"id"`
 
-	// Token Control Token.
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
 	Token string `json:"token"`
 }
 
@@ -50,27 +310,261 @@ type OverviewResponse struct {
 
 // SubdomainAcmeChallengeRequest Subdomain ACME Challenge Request.
 type SubdomainAcmeChallengeRequest struct {
-	// Token Control Token.
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
 	Token string `json:"token"`
 
+	// Ttl Requested lifetime of the tokens in seconds, capped by the server.
+	Ttl *int `json:"ttl,omitempty"`
+
 	// Values ACME Tokens.
 	Values []string `json:"values"`
 }
 
+// SubdomainAddressPolicyRequest Subdomain Address Policy Request.
+type SubdomainAddressPolicyRequest struct {
+	// Classes Address classes that may be synthesized. An empty list blocks all classes, while omitting the field restores the server default.
+	Classes *[]AddressClass `json:"classes,omitempty"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainCAARequest Subdomain CAA Request.
+type SubdomainCAARequest struct {
+	// Records CAA records.
+	Records []CAARecord `json:"records"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainDelegationRequest Subdomain Delegation Request.
+type SubdomainDelegationRequest struct {
+	// Ds Delegation signer records.
+	Ds *[]DelegationDS `json:"ds,omitempty"`
+
+	// Glue Glue addresses for nameservers within the delegated subdomain.
+	Glue *[]DelegationGlue `json:"glue,omitempty"`
+
+	// Nameservers Fully qualified nameserver names.
+	Nameservers []string `json:"nameservers"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainLabelRequest Subdomain Label Request.
+type SubdomainLabelRequest struct {
+	// Label Vanity label.
+	Label string `json:"label"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainLabelResponse Subdomain Label Response.
+type SubdomainLabelResponse struct {
+	// Domain Claimed domain.
+	Domain string `json:"domain"`
+}
+
+// SubdomainScheme Subdomain ID scheme.
+type SubdomainScheme string
+
+// SubdomainServiceRequest Subdomain Service Request.
+type SubdomainServiceRequest struct {
+	// Alpn Supported application protocols.
+	Alpn *[]ALPNProtocol `json:"alpn,omitempty"`
+
+	// Port Port the service listens on.
+	Port *int `json:"port,omitempty"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainTokenRequest Subdomain Token Request.
+type SubdomainTokenRequest struct {
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// SubdomainTokenResponse Subdomain Token Response.
+type SubdomainTokenResponse struct {
+	// Token New Control Token.
+	Token string `json:"token"`
+}
+
+// SubdomainWebhookRequest Subdomain Webhook Request.
+type SubdomainWebhookRequest struct {
+	// Events Events to deliver. Defaults to all events.
+	Events *[]WebhookEvent `json:"events,omitempty"`
+
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+
+	// Url HTTPS URL events are posted to.
+	Url *string `json:"url,omitempty"`
+}
+
+// SubdomainWebhookStatusRequest Subdomain Webhook Status Request.
+type SubdomainWebhookStatusRequest struct {
+	// Token Control Token. May be empty when the account key owning the subdomain is sent instead.
+	Token string `json:"token"`
+}
+
+// WebhookDelivery Webhook delivery.
+type WebhookDelivery struct {
+	// Attempts Number of delivery attempts made.
+	Attempts int `json:"attempts"`
+
+	// Created Time the event occurred.
+	Created time.Time `json:"created"`
+
+	// Event Subdomain event.
+	Event WebhookEvent `json:"event"`
+
+	// Id Delivery ID, also sent in the X-DSDM-Delivery header.
+	Id openapi_types.UUID `json:"id"`
+
+	// LastError Error from the most recent failed attempt.
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttempt Time of the next attempt while pending.
+	NextAttempt *time.Time `json:"next_attempt,omitempty"`
+
+	// Status Webhook delivery status.
+	Status WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus Webhook delivery status.
+type WebhookDeliveryStatus string
+
+// WebhookEvent Subdomain event.
+type WebhookEvent string
+
+// WebhookResponse Webhook Response.
+type WebhookResponse struct {
+	// Secret Secret used to sign deliveries. Absent when the webhook was removed.
+	Secret *string `json:"secret,omitempty"`
+}
+
+// WebhookStatusResponse Webhook Status Response.
+type WebhookStatusResponse struct {
+	// Deliveries Most recent deliveries, newest first.
+	Deliveries []WebhookDelivery `json:"deliveries"`
+
+	// Events Events that are delivered.
+	Events []WebhookEvent `json:"events"`
+
+	// Url Registered URL. Absent when no webhook is registered.
+	Url *string `json:"url,omitempty"`
+}
+
+// AccountAllocateSubdomainParams defines parameters for AccountAllocateSubdomain.
+type AccountAllocateSubdomainParams struct {
+	// Scheme ID scheme used for the subdomain label. Defaults to the server configured scheme.
+	Scheme *SubdomainScheme `form:"scheme,omitempty" json:"scheme,omitempty"`
+}
+
+// GenerateSubdomainParams defines parameters for GenerateSubdomain.
+type GenerateSubdomainParams struct {
+	// Scheme ID scheme used for the subdomain label. Defaults to the server configured scheme.
+	Scheme *SubdomainScheme `form:"scheme,omitempty" json:"scheme,omitempty"`
+}
+
+// ReportAbuseJSONRequestBody defines body for ReportAbuse for application/json ContentType.
+type ReportAbuseJSONRequestBody = AbuseReportRequest
+
+// CreateAccountJSONRequestBody defines body for CreateAccount for application/json ContentType.
+type CreateAccountJSONRequestBody = CreateAccountRequest
+
+// AccountAllocateSubdomainJSONRequestBody defines body for AccountAllocateSubdomain for application/json ContentType.
+type AccountAllocateSubdomainJSONRequestBody = AccountSubdomainRequest
+
+// AccountRevokeSubdomainsJSONRequestBody defines body for AccountRevokeSubdomains for application/json ContentType.
+type AccountRevokeSubdomainsJSONRequestBody = AccountRevokeRequest
+
 // SubdomainAcmeChallengeJSONRequestBody defines body for SubdomainAcmeChallenge for application/json ContentType.
 type SubdomainAcmeChallengeJSONRequestBody = SubdomainAcmeChallengeRequest
 
+// SubdomainAddressPolicyJSONRequestBody defines body for SubdomainAddressPolicy for application/json ContentType.
+type SubdomainAddressPolicyJSONRequestBody = SubdomainAddressPolicyRequest
+
+// SubdomainCaaJSONRequestBody defines body for SubdomainCaa for application/json ContentType.
+type SubdomainCaaJSONRequestBody = SubdomainCAARequest
+
+// SubdomainDelegationJSONRequestBody defines body for SubdomainDelegation for application/json ContentType.
+type SubdomainDelegationJSONRequestBody = SubdomainDelegationRequest
+
+// SubdomainClaimLabelJSONRequestBody defines body for SubdomainClaimLabel for application/json ContentType.
+type SubdomainClaimLabelJSONRequestBody = SubdomainLabelRequest
+
+// SubdomainServiceJSONRequestBody defines body for SubdomainService for application/json ContentType.
+type SubdomainServiceJSONRequestBody = SubdomainServiceRequest
+
+// SubdomainRotateTokenJSONRequestBody defines body for SubdomainRotateToken for application/json ContentType.
+type SubdomainRotateTokenJSONRequestBody = SubdomainTokenRequest
+
+// SubdomainWebhookJSONRequestBody defines body for SubdomainWebhook for application/json ContentType.
+type SubdomainWebhookJSONRequestBody = SubdomainWebhookRequest
+
+// SubdomainWebhookStatusJSONRequestBody defines body for SubdomainWebhookStatus for application/json ContentType.
+type SubdomainWebhookStatusJSONRequestBody = SubdomainWebhookStatusRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Server Overview
 	// (GET /)
 	GetOverview(w http.ResponseWriter, r *http.Request)
+	// Server Discovery
+	// (GET /.well-known/dsdm)
+	GetDiscovery(w http.ResponseWriter, r *http.Request)
+	// Report abuse
+	// (POST /abuse-report)
+	ReportAbuse(w http.ResponseWriter, r *http.Request)
+	// Create account
+	// (POST /account)
+	CreateAccount(w http.ResponseWriter, r *http.Request)
+	// List account subdomains
+	// (GET /account/subdomains)
+	AccountListSubdomains(w http.ResponseWriter, r *http.Request)
+	// Allocate account subdomain
+	// (POST /account/subdomains)
+	AccountAllocateSubdomain(w http.ResponseWriter, r *http.Request, params AccountAllocateSubdomainParams)
+	// Revoke account subdomains
+	// (POST /account/subdomains/revoke)
+	AccountRevokeSubdomains(w http.ResponseWriter, r *http.Request)
 	// Request new subdomain
 	// (POST /subdomain)
-	GenerateSubdomain(w http.ResponseWriter, r *http.Request)
+	GenerateSubdomain(w http.ResponseWriter, r *http.Request, params GenerateSubdomainParams)
 	// Set ACME challenge tokens
 	// (POST /subdomain/{subdomainId}/acme-challenge)
 	SubdomainAcmeChallenge(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Set address policy
+	// (POST /subdomain/{subdomainId}/address-policy)
+	SubdomainAddressPolicy(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Set CAA records
+	// (POST /subdomain/{subdomainId}/caa)
+	SubdomainCaa(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Set subdomain delegation
+	// (POST /subdomain/{subdomainId}/delegation)
+	SubdomainDelegation(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Claim subdomain label
+	// (POST /subdomain/{subdomainId}/label)
+	SubdomainClaimLabel(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Set service hints
+	// (POST /subdomain/{subdomainId}/service)
+	SubdomainService(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Rotate token
+	// (POST /subdomain/{subdomainId}/token)
+	SubdomainRotateToken(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Set webhook
+	// (POST /subdomain/{subdomainId}/webhook)
+	SubdomainWebhook(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
+	// Get webhook status
+	// (POST /subdomain/{subdomainId}/webhook/status)
+	SubdomainWebhookStatus(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -97,12 +591,12 @@ func (siw *ServerInterfaceWrapper) GetOverview(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GenerateSubdomain operation middleware
-func (siw *ServerInterfaceWrapper) GenerateSubdomain(w http.ResponseWriter, r *http.Request) {
+// GetDiscovery operation middleware
+func (siw *ServerInterfaceWrapper) GetDiscovery(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GenerateSubdomain(w, r)
+		siw.Handler.GetDiscovery(w, r)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -112,23 +606,27 @@ func (siw *ServerInterfaceWrapper) GenerateSubdomain(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// SubdomainAcmeChallenge operation middleware
-func (siw *ServerInterfaceWrapper) SubdomainAcmeChallenge(w http.ResponseWriter, r *http.Request) {
+// ReportAbuse operation middleware
+func (siw *ServerInterfaceWrapper) ReportAbuse(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "subdomainId" -------------
-	var subdomainId openapi_types.UUID
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportAbuse(w, r)
+	})
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateAccount operation middleware
+func (siw *ServerInterfaceWrapper) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SubdomainAcmeChallenge(w, r, subdomainId)
+		siw.Handler.CreateAccount(w, r)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -138,268 +636,1648 @@ func (siw *ServerInterfaceWrapper) SubdomainAcmeChallenge(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// AccountListSubdomains operation middleware
+func (siw *ServerInterfaceWrapper) AccountListSubdomains(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	ctx = context.WithValue(ctx, AccountKeyScopes, []string{""})
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AccountListSubdomains(w, r)
+	})
 
-type UnmarshallingParamError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *UnmarshallingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *UnmarshallingParamError) Unwrap() error {
-	return e.Err
-}
+// AccountAllocateSubdomain operation middleware
+func (siw *ServerInterfaceWrapper) AccountAllocateSubdomain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type RequiredParamError struct {
-	ParamName string
-}
+	var err error
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	ctx = context.WithValue(ctx, AccountKeyScopes, []string{""})
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AccountAllocateSubdomainParams
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// ------------- Optional query parameter "scheme" -------------
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "scheme", r.URL.Query(), &params.Scheme)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheme", Err: err})
+		return
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AccountAllocateSubdomain(w, r, params)
+	})
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+// AccountRevokeSubdomains operation middleware
+func (siw *ServerInterfaceWrapper) AccountRevokeSubdomains(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+	ctx = context.WithValue(ctx, AccountKeyScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AccountRevokeSubdomains(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+// GenerateSubdomain operation middleware
+func (siw *ServerInterfaceWrapper) GenerateSubdomain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GenerateSubdomainParams
+
+	// ------------- Optional query parameter "scheme" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scheme", r.URL.Query(), &params.Scheme)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheme", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GenerateSubdomain(w, r, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+// SubdomainAcmeChallenge operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainAcmeChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainAcmeChallenge(w, r, subdomainId)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
+// SubdomainAddressPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainAddressPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainAddressPolicy(w, r, subdomainId)
 	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
+// SubdomainCaa operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainCaa(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainCaa(w, r, subdomainId)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SubdomainDelegation operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainDelegation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainDelegation(w, r, subdomainId)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SubdomainClaimLabel operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainClaimLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainClaimLabel(w, r, subdomainId)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SubdomainService operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainService(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainService(w, r, subdomainId)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SubdomainRotateToken operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainRotateToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainRotateToken(w, r, subdomainId)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SubdomainWebhook operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainWebhook(w, r, subdomainId)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SubdomainWebhookStatus operation middleware
+func (siw *ServerInterfaceWrapper) SubdomainWebhookStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "subdomainId" -------------
+	var subdomainId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "subdomainId", runtime.ParamLocationPath, chi.URLParam(r, "subdomainId"), &subdomainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subdomainId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubdomainWebhookStatus(w, r, subdomainId)
 	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshallingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshallingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshallingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/", wrapper.GetOverview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/dsdm", wrapper.GetDiscovery)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/abuse-report", wrapper.ReportAbuse)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/account", wrapper.CreateAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/account/subdomains", wrapper.AccountListSubdomains)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/account/subdomains", wrapper.AccountAllocateSubdomain)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/account/subdomains/revoke", wrapper.AccountRevokeSubdomains)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain", wrapper.GenerateSubdomain)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/acme-challenge", wrapper.SubdomainAcmeChallenge)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/address-policy", wrapper.SubdomainAddressPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/caa", wrapper.SubdomainCaa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/delegation", wrapper.SubdomainDelegation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/label", wrapper.SubdomainClaimLabel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/service", wrapper.SubdomainService)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/token", wrapper.SubdomainRotateToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/webhook", wrapper.SubdomainWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/subdomain/{subdomainId}/webhook/status", wrapper.SubdomainWebhookStatus)
+	})
+
+	return r
+}
+
+type GetOverviewRequestObject struct {
+}
+
+type GetOverviewResponseObject interface {
+	VisitGetOverviewResponse(w http.ResponseWriter) error
+}
+
+type GetOverview200JSONResponse OverviewResponse
+
+func (response GetOverview200JSONResponse) VisitGetOverviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDiscoveryRequestObject struct {
+}
+
+type GetDiscoveryResponseObject interface {
+	VisitGetDiscoveryResponse(w http.ResponseWriter) error
+}
+
+type GetDiscovery200JSONResponse DiscoveryResponse
+
+func (response GetDiscovery200JSONResponse) VisitGetDiscoveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportAbuseRequestObject struct {
+	Body *ReportAbuseJSONRequestBody
+}
+
+type ReportAbuseResponseObject interface {
+	VisitReportAbuseResponse(w http.ResponseWriter) error
+}
+
+type ReportAbuse200JSONResponse AbuseReportResponse
+
+func (response ReportAbuse200JSONResponse) VisitReportAbuseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportAbuse429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type ReportAbuse429JSONResponse struct {
+	Body    ErrorResponse
+	Headers ReportAbuse429ResponseHeaders
+}
+
+func (response ReportAbuse429JSONResponse) VisitReportAbuseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAccountRequestObject struct {
+	Body *CreateAccountJSONRequestBody
+}
+
+type CreateAccountResponseObject interface {
+	VisitCreateAccountResponse(w http.ResponseWriter) error
+}
+
+type CreateAccount200JSONResponse AccountResponse
+
+func (response CreateAccount200JSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAccount429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type CreateAccount429JSONResponse struct {
+	Body    ErrorResponse
+	Headers CreateAccount429ResponseHeaders
+}
+
+func (response CreateAccount429JSONResponse) VisitCreateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AccountListSubdomainsRequestObject struct {
+}
+
+type AccountListSubdomainsResponseObject interface {
+	VisitAccountListSubdomainsResponse(w http.ResponseWriter) error
+}
+
+type AccountListSubdomains200JSONResponse AccountSubdomainsResponse
+
+func (response AccountListSubdomains200JSONResponse) VisitAccountListSubdomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountListSubdomains401JSONResponse ErrorResponse
+
+func (response AccountListSubdomains401JSONResponse) VisitAccountListSubdomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountListSubdomains429JSONResponse ErrorResponse
+
+func (response AccountListSubdomains429JSONResponse) VisitAccountListSubdomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountAllocateSubdomainRequestObject struct {
+	Params AccountAllocateSubdomainParams
+	Body   *AccountAllocateSubdomainJSONRequestBody
+}
+
+type AccountAllocateSubdomainResponseObject interface {
+	VisitAccountAllocateSubdomainResponse(w http.ResponseWriter) error
+}
+
+type AccountAllocateSubdomain200JSONResponse NewSubdomainResponse
+
+func (response AccountAllocateSubdomain200JSONResponse) VisitAccountAllocateSubdomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountAllocateSubdomain400JSONResponse ErrorResponse
+
+func (response AccountAllocateSubdomain400JSONResponse) VisitAccountAllocateSubdomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountAllocateSubdomain401JSONResponse ErrorResponse
+
+func (response AccountAllocateSubdomain401JSONResponse) VisitAccountAllocateSubdomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountAllocateSubdomain429JSONResponse ErrorResponse
+
+func (response AccountAllocateSubdomain429JSONResponse) VisitAccountAllocateSubdomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountRevokeSubdomainsRequestObject struct {
+	Body *AccountRevokeSubdomainsJSONRequestBody
+}
+
+type AccountRevokeSubdomainsResponseObject interface {
+	VisitAccountRevokeSubdomainsResponse(w http.ResponseWriter) error
+}
+
+type AccountRevokeSubdomains200JSONResponse AccountRevokeResponse
+
+func (response AccountRevokeSubdomains200JSONResponse) VisitAccountRevokeSubdomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountRevokeSubdomains401JSONResponse ErrorResponse
+
+func (response AccountRevokeSubdomains401JSONResponse) VisitAccountRevokeSubdomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AccountRevokeSubdomains429JSONResponse ErrorResponse
+
+func (response AccountRevokeSubdomains429JSONResponse) VisitAccountRevokeSubdomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateSubdomainRequestObject struct {
+	Params GenerateSubdomainParams
+}
+
+type GenerateSubdomainResponseObject interface {
+	VisitGenerateSubdomainResponse(w http.ResponseWriter) error
+}
+
+type GenerateSubdomain200JSONResponse NewSubdomainResponse
+
+func (response GenerateSubdomain200JSONResponse) VisitGenerateSubdomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateSubdomain400JSONResponse ErrorResponse
+
+func (response GenerateSubdomain400JSONResponse) VisitGenerateSubdomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateSubdomain429JSONResponse ErrorResponse
+
+func (response GenerateSubdomain429JSONResponse) VisitGenerateSubdomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainAcmeChallengeRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainAcmeChallengeJSONRequestBody
+}
+
+type SubdomainAcmeChallengeResponseObject interface {
+	VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error
+}
+
+type SubdomainAcmeChallenge200JSONResponse AcmeChallengeResponse
+
+func (response SubdomainAcmeChallenge200JSONResponse) VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainAcmeChallenge403JSONResponse ErrorResponse
+
+func (response SubdomainAcmeChallenge403JSONResponse) VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainAcmeChallenge429JSONResponse ErrorResponse
+
+func (response SubdomainAcmeChallenge429JSONResponse) VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainAddressPolicyRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainAddressPolicyJSONRequestBody
+}
+
+type SubdomainAddressPolicyResponseObject interface {
+	VisitSubdomainAddressPolicyResponse(w http.ResponseWriter) error
+}
+
+type SubdomainAddressPolicy200JSONResponse AddressPolicyResponse
+
+func (response SubdomainAddressPolicy200JSONResponse) VisitSubdomainAddressPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainAddressPolicy403JSONResponse ErrorResponse
+
+func (response SubdomainAddressPolicy403JSONResponse) VisitSubdomainAddressPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainAddressPolicy429JSONResponse ErrorResponse
+
+func (response SubdomainAddressPolicy429JSONResponse) VisitSubdomainAddressPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainCaaRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainCaaJSONRequestBody
+}
+
+type SubdomainCaaResponseObject interface {
+	VisitSubdomainCaaResponse(w http.ResponseWriter) error
+}
+
+type SubdomainCaa200Response struct {
+}
+
+func (response SubdomainCaa200Response) VisitSubdomainCaaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type SubdomainCaa400JSONResponse ErrorResponse
+
+func (response SubdomainCaa400JSONResponse) VisitSubdomainCaaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainCaa403JSONResponse ErrorResponse
+
+func (response SubdomainCaa403JSONResponse) VisitSubdomainCaaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainCaa429JSONResponse ErrorResponse
+
+func (response SubdomainCaa429JSONResponse) VisitSubdomainCaaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainDelegationRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainDelegationJSONRequestBody
+}
+
+type SubdomainDelegationResponseObject interface {
+	VisitSubdomainDelegationResponse(w http.ResponseWriter) error
+}
+
+type SubdomainDelegation200Response struct {
+}
+
+func (response SubdomainDelegation200Response) VisitSubdomainDelegationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type SubdomainDelegation400JSONResponse ErrorResponse
+
+func (response SubdomainDelegation400JSONResponse) VisitSubdomainDelegationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainDelegation403JSONResponse ErrorResponse
+
+func (response SubdomainDelegation403JSONResponse) VisitSubdomainDelegationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainDelegation429JSONResponse ErrorResponse
+
+func (response SubdomainDelegation429JSONResponse) VisitSubdomainDelegationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainClaimLabelRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainClaimLabelJSONRequestBody
+}
+
+type SubdomainClaimLabelResponseObject interface {
+	VisitSubdomainClaimLabelResponse(w http.ResponseWriter) error
+}
+
+type SubdomainClaimLabel200JSONResponse SubdomainLabelResponse
+
+func (response SubdomainClaimLabel200JSONResponse) VisitSubdomainClaimLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainClaimLabel400JSONResponse ErrorResponse
+
+func (response SubdomainClaimLabel400JSONResponse) VisitSubdomainClaimLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainClaimLabel403JSONResponse ErrorResponse
+
+func (response SubdomainClaimLabel403JSONResponse) VisitSubdomainClaimLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainClaimLabel409JSONResponse ErrorResponse
+
+func (response SubdomainClaimLabel409JSONResponse) VisitSubdomainClaimLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainClaimLabel429JSONResponse ErrorResponse
+
+func (response SubdomainClaimLabel429JSONResponse) VisitSubdomainClaimLabelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainServiceRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainServiceJSONRequestBody
+}
+
+type SubdomainServiceResponseObject interface {
+	VisitSubdomainServiceResponse(w http.ResponseWriter) error
+}
+
+type SubdomainService200Response struct {
+}
+
+func (response SubdomainService200Response) VisitSubdomainServiceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type SubdomainService403JSONResponse ErrorResponse
+
+func (response SubdomainService403JSONResponse) VisitSubdomainServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainService429JSONResponse ErrorResponse
+
+func (response SubdomainService429JSONResponse) VisitSubdomainServiceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainRotateTokenRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainRotateTokenJSONRequestBody
+}
+
+type SubdomainRotateTokenResponseObject interface {
+	VisitSubdomainRotateTokenResponse(w http.ResponseWriter) error
+}
+
+type SubdomainRotateToken200JSONResponse SubdomainTokenResponse
+
+func (response SubdomainRotateToken200JSONResponse) VisitSubdomainRotateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainRotateToken403JSONResponse ErrorResponse
+
+func (response SubdomainRotateToken403JSONResponse) VisitSubdomainRotateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainRotateToken429JSONResponse ErrorResponse
+
+func (response SubdomainRotateToken429JSONResponse) VisitSubdomainRotateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainWebhookRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainWebhookJSONRequestBody
+}
+
+type SubdomainWebhookResponseObject interface {
+	VisitSubdomainWebhookResponse(w http.ResponseWriter) error
+}
+
+type SubdomainWebhook200JSONResponse WebhookResponse
+
+func (response SubdomainWebhook200JSONResponse) VisitSubdomainWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainWebhook400JSONResponse ErrorResponse
+
+func (response SubdomainWebhook400JSONResponse) VisitSubdomainWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainWebhook403JSONResponse ErrorResponse
+
+func (response SubdomainWebhook403JSONResponse) VisitSubdomainWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainWebhook429JSONResponse ErrorResponse
+
+func (response SubdomainWebhook429JSONResponse) VisitSubdomainWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainWebhookStatusRequestObject struct {
+	SubdomainId openapi_types.UUID `json:"subdomainId"`
+	Body        *SubdomainWebhookStatusJSONRequestBody
+}
+
+type SubdomainWebhookStatusResponseObject interface {
+	VisitSubdomainWebhookStatusResponse(w http.ResponseWriter) error
+}
+
+type SubdomainWebhookStatus200JSONResponse WebhookStatusResponse
+
+func (response SubdomainWebhookStatus200JSONResponse) VisitSubdomainWebhookStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainWebhookStatus403JSONResponse ErrorResponse
+
+func (response SubdomainWebhookStatus403JSONResponse) VisitSubdomainWebhookStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SubdomainWebhookStatus429JSONResponse ErrorResponse
+
+func (response SubdomainWebhookStatus429JSONResponse) VisitSubdomainWebhookStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Server Overview
+	// (GET /)
+	GetOverview(ctx context.Context, request GetOverviewRequestObject) (GetOverviewResponseObject, error)
+	// Server Discovery
+	// (GET /.well-known/dsdm)
+	GetDiscovery(ctx context.Context, request GetDiscoveryRequestObject) (GetDiscoveryResponseObject, error)
+	// Report abuse
+	// (POST /abuse-report)
+	ReportAbuse(ctx context.Context, request ReportAbuseRequestObject) (ReportAbuseResponseObject, error)
+	// Create account
+	// (POST /account)
+	CreateAccount(ctx context.Context, request CreateAccountRequestObject) (CreateAccountResponseObject, error)
+	// List account subdomains
+	// (GET /account/subdomains)
+	AccountListSubdomains(ctx context.Context, request AccountListSubdomainsRequestObject) (AccountListSubdomainsResponseObject, error)
+	// Allocate account subdomain
+	// (POST /account/subdomains)
+	AccountAllocateSubdomain(ctx context.Context, request AccountAllocateSubdomainRequestObject) (AccountAllocateSubdomainResponseObject, error)
+	// Revoke account subdomains
+	// (POST /account/subdomains/revoke)
+	AccountRevokeSubdomains(ctx context.Context, request AccountRevokeSubdomainsRequestObject) (AccountRevokeSubdomainsResponseObject, error)
+	// Request new subdomain
+	// (POST /subdomain)
+	GenerateSubdomain(ctx context.Context, request GenerateSubdomainRequestObject) (GenerateSubdomainResponseObject, error)
+	// Set ACME challenge tokens
+	// (POST /subdomain/{subdomainId}/acme-challenge)
+	SubdomainAcmeChallenge(ctx context.Context, request SubdomainAcmeChallengeRequestObject) (SubdomainAcmeChallengeResponseObject, error)
+	// Set address policy
+	// (POST /subdomain/{subdomainId}/address-policy)
+	SubdomainAddressPolicy(ctx context.Context, request SubdomainAddressPolicyRequestObject) (SubdomainAddressPolicyResponseObject, error)
+	// Set CAA records
+	// (POST /subdomain/{subdomainId}/caa)
+	SubdomainCaa(ctx context.Context, request SubdomainCaaRequestObject) (SubdomainCaaResponseObject, error)
+	// Set subdomain delegation
+	// (POST /subdomain/{subdomainId}/delegation)
+	SubdomainDelegation(ctx context.Context, request SubdomainDelegationRequestObject) (SubdomainDelegationResponseObject, error)
+	// Claim subdomain label
+	// (POST /subdomain/{subdomainId}/label)
+	SubdomainClaimLabel(ctx context.Context, request SubdomainClaimLabelRequestObject) (SubdomainClaimLabelResponseObject, error)
+	// Set service hints
+	// (POST /subdomain/{subdomainId}/service)
+	SubdomainService(ctx context.Context, request SubdomainServiceRequestObject) (SubdomainServiceResponseObject, error)
+	// Rotate token
+	// (POST /subdomain/{subdomainId}/token)
+	SubdomainRotateToken(ctx context.Context, request SubdomainRotateTokenRequestObject) (SubdomainRotateTokenResponseObject, error)
+	// Set webhook
+	// (POST /subdomain/{subdomainId}/webhook)
+	SubdomainWebhook(ctx context.Context, request SubdomainWebhookRequestObject) (SubdomainWebhookResponseObject, error)
+	// Get webhook status
+	// (POST /subdomain/{subdomainId}/webhook/status)
+	SubdomainWebhookStatus(ctx context.Context, request SubdomainWebhookStatusRequestObject) (SubdomainWebhookStatusResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetOverview operation middleware
+func (sh *strictHandler) GetOverview(w http.ResponseWriter, r *http.Request) {
+	var request GetOverviewRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOverview(ctx, request.(GetOverviewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOverview")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOverviewResponseObject); ok {
+		if err := validResponse.VisitGetOverviewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetDiscovery operation middleware
+func (sh *strictHandler) GetDiscovery(w http.ResponseWriter, r *http.Request) {
+	var request GetDiscoveryRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDiscovery(ctx, request.(GetDiscoveryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDiscovery")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDiscoveryResponseObject); ok {
+		if err := validResponse.VisitGetDiscoveryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ReportAbuse operation middleware
+func (sh *strictHandler) ReportAbuse(w http.ResponseWriter, r *http.Request) {
+	var request ReportAbuseRequestObject
+
+	var body ReportAbuseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReportAbuse(ctx, request.(ReportAbuseRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReportAbuse")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReportAbuseResponseObject); ok {
+		if err := validResponse.VisitReportAbuseResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateAccount operation middleware
+func (sh *strictHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var request CreateAccountRequestObject
+
+	var body CreateAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAccount(ctx, request.(CreateAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAccountResponseObject); ok {
+		if err := validResponse.VisitCreateAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// AccountListSubdomains operation middleware
+func (sh *strictHandler) AccountListSubdomains(w http.ResponseWriter, r *http.Request) {
+	var request AccountListSubdomainsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AccountListSubdomains(ctx, request.(AccountListSubdomainsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AccountListSubdomains")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AccountListSubdomainsResponseObject); ok {
+		if err := validResponse.VisitAccountListSubdomainsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// AccountAllocateSubdomain operation middleware
+func (sh *strictHandler) AccountAllocateSubdomain(w http.ResponseWriter, r *http.Request, params AccountAllocateSubdomainParams) {
+	var request AccountAllocateSubdomainRequestObject
+
+	request.Params = params
+
+	var body AccountAllocateSubdomainJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AccountAllocateSubdomain(ctx, request.(AccountAllocateSubdomainRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AccountAllocateSubdomain")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AccountAllocateSubdomainResponseObject); ok {
+		if err := validResponse.VisitAccountAllocateSubdomainResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// AccountRevokeSubdomains operation middleware
+func (sh *strictHandler) AccountRevokeSubdomains(w http.ResponseWriter, r *http.Request) {
+	var request AccountRevokeSubdomainsRequestObject
+
+	var body AccountRevokeSubdomainsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AccountRevokeSubdomains(ctx, request.(AccountRevokeSubdomainsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AccountRevokeSubdomains")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AccountRevokeSubdomainsResponseObject); ok {
+		if err := validResponse.VisitAccountRevokeSubdomainsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GenerateSubdomain operation middleware
+func (sh *strictHandler) GenerateSubdomain(w http.ResponseWriter, r *http.Request, params GenerateSubdomainParams) {
+	var request GenerateSubdomainRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GenerateSubdomain(ctx, request.(GenerateSubdomainRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GenerateSubdomain")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GenerateSubdomainResponseObject); ok {
+		if err := validResponse.VisitGenerateSubdomainResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// SubdomainAcmeChallenge operation middleware
+func (sh *strictHandler) SubdomainAcmeChallenge(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainAcmeChallengeRequestObject
+
+	request.SubdomainId = subdomainId
 
-	if r == nil {
-		r = chi.NewRouter()
+	var body SubdomainAcmeChallengeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SubdomainAcmeChallenge(ctx, request.(SubdomainAcmeChallengeRequestObject))
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SubdomainAcmeChallenge")
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/", wrapper.GetOverview)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/subdomain", wrapper.GenerateSubdomain)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/subdomain/{subdomainId}/acme-challenge", wrapper.SubdomainAcmeChallenge)
-	})
-
-	return r
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetOverviewRequestObject struct {
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SubdomainAcmeChallengeResponseObject); ok {
+		if err := validResponse.VisitSubdomainAcmeChallengeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type GetOverviewResponseObject interface {
-	VisitGetOverviewResponse(w http.ResponseWriter) error
-}
+// SubdomainAddressPolicy operation middleware
+func (sh *strictHandler) SubdomainAddressPolicy(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainAddressPolicyRequestObject
 
-type GetOverview200JSONResponse OverviewResponse
+	request.SubdomainId = subdomainId
 
-func (response GetOverview200JSONResponse) VisitGetOverviewResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var body SubdomainAddressPolicyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SubdomainAddressPolicy(ctx, request.(SubdomainAddressPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SubdomainAddressPolicy")
+	}
 
-type GenerateSubdomainRequestObject struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GenerateSubdomainResponseObject interface {
-	VisitGenerateSubdomainResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SubdomainAddressPolicyResponseObject); ok {
+		if err := validResponse.VisitSubdomainAddressPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type GenerateSubdomain200JSONResponse NewSubdomainResponse
+// SubdomainCaa operation middleware
+func (sh *strictHandler) SubdomainCaa(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainCaaRequestObject
 
-func (response GenerateSubdomain200JSONResponse) VisitGenerateSubdomainResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.SubdomainId = subdomainId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SubdomainCaaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GenerateSubdomain429JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SubdomainCaa(ctx, request.(SubdomainCaaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SubdomainCaa")
+	}
 
-func (response GenerateSubdomain429JSONResponse) VisitGenerateSubdomainResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(429)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SubdomainCaaResponseObject); ok {
+		if err := validResponse.VisitSubdomainCaaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type SubdomainAcmeChallengeRequestObject struct {
-	SubdomainId openapi_types.UUID `json:"subdomainId"`
-	Body        *SubdomainAcmeChallengeJSONRequestBody
-}
+// SubdomainDelegation operation middleware
+func (sh *strictHandler) SubdomainDelegation(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainDelegationRequestObject
 
-type SubdomainAcmeChallengeResponseObject interface {
-	VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error
-}
+	request.SubdomainId = subdomainId
 
-type SubdomainAcmeChallenge200Response struct {
-}
+	var body SubdomainDelegationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response SubdomainAcmeChallenge200Response) VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SubdomainDelegation(ctx, request.(SubdomainDelegationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SubdomainDelegation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SubdomainDelegationResponseObject); ok {
+		if err := validResponse.VisitSubdomainDelegationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type SubdomainAcmeChallenge403JSONResponse ErrorResponse
+// SubdomainClaimLabel operation middleware
+func (sh *strictHandler) SubdomainClaimLabel(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainClaimLabelRequestObject
 
-func (response SubdomainAcmeChallenge403JSONResponse) VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	request.SubdomainId = subdomainId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SubdomainClaimLabelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type SubdomainAcmeChallenge429JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SubdomainClaimLabel(ctx, request.(SubdomainClaimLabelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SubdomainClaimLabel")
+	}
 
-func (response SubdomainAcmeChallenge429JSONResponse) VisitSubdomainAcmeChallengeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(429)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SubdomainClaimLabelResponseObject); ok {
+		if err := validResponse.VisitSubdomainClaimLabelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Server Overview
-	// (GET /)
-	GetOverview(ctx context.Context, request GetOverviewRequestObject) (GetOverviewResponseObject, error)
-	// Request new subdomain
-	// (POST /subdomain)
-	GenerateSubdomain(ctx context.Context, request GenerateSubdomainRequestObject) (GenerateSubdomainResponseObject, error)
-	// Set ACME challenge tokens
-	// (POST /subdomain/{subdomainId}/acme-challenge)
-	SubdomainAcmeChallenge(ctx context.Context, request SubdomainAcmeChallengeRequestObject) (SubdomainAcmeChallengeResponseObject, error)
-}
+// SubdomainService operation middleware
+func (sh *strictHandler) SubdomainService(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainServiceRequestObject
 
-type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+	request.SubdomainId = subdomainId
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	var body SubdomainServiceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SubdomainService(ctx, request.(SubdomainServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SubdomainService")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SubdomainServiceResponseObject); ok {
+		if err := validResponse.VisitSubdomainServiceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// SubdomainRotateToken operation middleware
+func (sh *strictHandler) SubdomainRotateToken(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainRotateTokenRequestObject
 
-// GetOverview operation middleware
-func (sh *strictHandler) GetOverview(w http.ResponseWriter, r *http.Request) {
-	var request GetOverviewRequestObject
+	request.SubdomainId = subdomainId
+
+	var body SubdomainRotateTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetOverview(ctx, request.(GetOverviewRequestObject))
+		return sh.ssi.SubdomainRotateToken(ctx, request.(SubdomainRotateTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetOverview")
+		handler = middleware(handler, "SubdomainRotateToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetOverviewResponseObject); ok {
-		if err := validResponse.VisitGetOverviewResponse(w); err != nil {
+	} else if validResponse, ok := response.(SubdomainRotateTokenResponseObject); ok {
+		if err := validResponse.VisitSubdomainRotateTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -407,23 +2285,32 @@ func (sh *strictHandler) GetOverview(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GenerateSubdomain operation middleware
-func (sh *strictHandler) GenerateSubdomain(w http.ResponseWriter, r *http.Request) {
-	var request GenerateSubdomainRequestObject
+// SubdomainWebhook operation middleware
+func (sh *strictHandler) SubdomainWebhook(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainWebhookRequestObject
+
+	request.SubdomainId = subdomainId
+
+	var body SubdomainWebhookJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GenerateSubdomain(ctx, request.(GenerateSubdomainRequestObject))
+		return sh.ssi.SubdomainWebhook(ctx, request.(SubdomainWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GenerateSubdomain")
+		handler = middleware(handler, "SubdomainWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GenerateSubdomainResponseObject); ok {
-		if err := validResponse.VisitGenerateSubdomainResponse(w); err != nil {
+	} else if validResponse, ok := response.(SubdomainWebhookResponseObject); ok {
+		if err := validResponse.VisitSubdomainWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -431,13 +2318,13 @@ func (sh *strictHandler) GenerateSubdomain(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// SubdomainAcmeChallenge operation middleware
-func (sh *strictHandler) SubdomainAcmeChallenge(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
-	var request SubdomainAcmeChallengeRequestObject
+// SubdomainWebhookStatus operation middleware
+func (sh *strictHandler) SubdomainWebhookStatus(w http.ResponseWriter, r *http.Request, subdomainId openapi_types.UUID) {
+	var request SubdomainWebhookStatusRequestObject
 
 	request.SubdomainId = subdomainId
 
-	var body SubdomainAcmeChallengeJSONRequestBody
+	var body SubdomainWebhookStatusJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -445,18 +2332,18 @@ func (sh *strictHandler) SubdomainAcmeChallenge(w http.ResponseWriter, r *http.R
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.SubdomainAcmeChallenge(ctx, request.(SubdomainAcmeChallengeRequestObject))
+		return sh.ssi.SubdomainWebhookStatus(ctx, request.(SubdomainWebhookStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "SubdomainAcmeChallenge")
+		handler = middleware(handler, "SubdomainWebhookStatus")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(SubdomainAcmeChallengeResponseObject); ok {
-		if err := validResponse.VisitSubdomainAcmeChallengeResponse(w); err != nil {
+	} else if validResponse, ok := response.(SubdomainWebhookStatusResponseObject); ok {
+		if err := validResponse.VisitSubdomainWebhookStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -467,23 +2354,107 @@ func (sh *strictHandler) SubdomainAcmeChallenge(w http.ResponseWriter, r *http.R
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xW32/bNhD+VwhuDxsgW87PLnpamhSDgWXrkmAoWgTDSTxZ7ERSJSknQuD/fSBp/bCl",
-	"ZGmzx70Ylo53x/vu++70SDMlKiVRWkOTR2qyAgX4v++0VvoaTaWkQfei0qpCbTl6Mzqz+2ObCmlCjdVc",
-	"rugmogKNgRVO2DYR1fil5hoZTT5tQ/QOdxG13JbOYzd51AZS6WfMrEvyG97f1ClTArgcXpKhyTSvLFeS",
-	"Ju4U6Y6R9tycRnvFhANj//OyVBlYZCScmPdX6evlbOzYJ11eOqdcaQGWJrSuOZsKYtXfOHGBCyWtViW5",
-	"deaJ7HuIhtg+VNQWNUB1ErQJcH9fo15zvH+6+1nJUdq/eDW+8m2BJJgNWb4nwJhGYyaRW6M23mkEH+o1",
-	"avJnsP973W2gaHCxQd2jeiZq7oA5zwReFFCWKFd4jV9qNPa5Bp9fXL0jnQPZeow59o0djugaynpL011y",
-	"usTezaPLLQp/SsDDryhXtqDJ4cnJREABD8tw+GDRmUFraEbAtlza3mGA6fN4jQB2gbnMlSePkhYyDyoK",
-	"4CVN2lc/s0bOGdfOJaIShAsxeLeJ9jGorRJeoJVWVmWqJLnShBtTc7kiIBkRIGHlHlgjQfAMyrIh0Anb",
-	"tHV4EEue4ZbwnKG0POeoaUKvlrf9hdzDpkfiMsQdDJorlxIFSkvet9f64fLm8upHOiA9PZgvXBxVoYSK",
-	"04Qezd2riFZgC9/K2P2scIJ/12hrLQ0BSdSW3UTlxBZIjBePq8bRD5zDktGE/oK2FQJ1XQ5a8HkOF4u2",
-	"MSh9NqiqkmfeOf5sgkbxAURVenB2ighlhNXhjN9rzGlCv4v73RJvF0s8kqJnxr62ssyPDGcytRCgm34o",
-	"dDU4a9y1z88oZSah8pwkQCTe9/2eAki6R+wa+WqY3Gqgx2dv8lPMYHZ6+OZ0dnx2djRLc0xnJ0dZmqYp",
-	"nOaLn7qxndCPH4oi/fDWfPzj5ahODvZJZFuKdgKYuyzHh2dfV9h2+1Or1EyAbGY6gGwG6zyht0o5+TWk",
-	"tZIC1khSREkEMCS5VoI0qtY7m+LFZe9+JUzUO76AS7tPrJYgO/TYo1f82P1dsk0MmcBZ1k69p7l3gzYs",
-	"iO4s8X02fkw9w8Xp8epngwaBFrWhyaf9dMvLbgh0nbbKIUusckm6Fr6UlU5Yfh71428ABB1uC6trHHbu",
-	"VV9Em7sQGo19q1jzdex8jZSe32ub3QXpSt5MD4kXCm9x9G3C43INJWezdkEPRFegW4ZrzpAFshFuiFSW",
-	"eA9PvB2G/JeCW4Zrhbz/j5an9R9ChlUdlFxr9yFUWFuZJI7XB/Phd8/d5p8AAAD//w3BGXyqDQAA",
+	"H4sIAAAAAAAC/+xd/XLjNpJ/FRRvq3b3SpIlf0w8vrq6VcbZrO8mE9/YyebizHkhsiVhTQIcALRHSfnd",
+	"r/BFgiRIUR57Nzn7n2REgkCju/HrRqPR/iWKWZYzClSK6OSXSMRryLD+5/zt+btzziSLWap+JyBiTnJJ",
+	"GI1OonmepyTG6hfKbatJNIqAFll0chWtpcz3ZpNZNIrW++o/B9GHUSSJTCE6qfc9iuQmV0+F5ISuovtR",
+	"NF8UAt5Dzrh8Dx8LEDJAgWqDTCNkWykKcs5y4JKAnkXMqMRx4PNv9T9wimwLlIDEJBWILZFcA+K6Y+Cq",
+	"ywx/egt0JdfRyf7RUYDehGWY0PYghjpIkGnQ6usg0BcHLFigr9Pql6MRKxY0O51Op61edbcfC8IhUcKx",
+	"5JZj+ZJpc77sjS3+DrFsyUfkjArYKiDTrC0hknTxDZ2dquZLxjMso5OoKEgSbZsbSTqnYwkNzSeOWUH7",
+	"5mIa7DgN99WgeYyiG9h09zE/P0M3sJkMYYDpymdDY359LLhlN9C96Eo+qGbdy44kov3x2Wm5ukSxMEoo",
+	"kGSI695UL0RCpr/cyqwMfzozjWdK4TNC3c+yMeYcbwL8EUHW+PPezp/timIZ1KUvZs7JYC6tsUR3wMEy",
+	"K9mNW70ccaR0c2Wr2lw4StvzKV8hdkchQYsNwhRh810AsDlgGeLLJcmgzhV0hwXCacpi9UVtiSVYwliS",
+	"DELMqPXbB7MrcgsUMeoGIYxOoh3gf+5o8/C/9TFJ+ng2EDo6tamDa54SDeFZCGJKI+Ik1lafSisGaM5W",
+	"zKmY0gk7vZItLX7StqQldwaY+/vueW7Hj7Kp2I4hVdseHKkwQv0qEeF3HJbRSfQve5V/t2edu72WdLYB",
+	"hDdGj5BFP05k8GaN0xToqg8+33zzFSrb9UwbPuWEg+hReMlugAp0R9IUCclytABCV0gAv90FLKQMeL9f",
+	"LZcQS3ILKCVLUJ86RbKjEooExIwmwlvzhEpYAW+xVw0xKmdU43CIaSHuJgkHId6kWARYoh8rAs/OETYt",
+	"fVc9ZSxf4PhG85jcYqmGSAm9GSv0UqQVKVYvi0VK4hp9/rAhN968P2cpiTc9QjfNkGnXI/RYDQSiTx52",
+	"fsg2tXZT6cACkNhQuQZBfm6Yz97F4s9x20JxBLZ51OBBQIZv5vP3EDMeAPE38zni+l2bJ8sUr9pf/DnF",
+	"KzFCs/1jlGF+I+yWRnWBsEAxJ5LEOLVoR7Iis1iXEWp+TdtqO4okXm3j15v5/BJr6d/itAhI+0yIAjhy",
+	"xojINWIOmXPMcQYSuBghxpWfQFgCS/Td+7fDgNkXhuaModkR48mlYndYFpd4FRaEZf8GSbzylxFR84pG",
+	"5v93JFUda+obo17iVTVktVbeaCta+l0dttC0QpWX2WEIKc6gxwKq1+UmsvLEPP7OwrvIch4hagOMPIUU",
+	"VtpxOr0I+VruLRJkRYF3ajlOV4wTuc7Uj100NiGrICP/Ap8Q0JglyjnTbZoM2D8OeXq66bV5vhslN7C5",
+	"tuun/OzV0dHBlg8bSu16GXksqZPlfvlqVxNDr5i+Dq7ZdzgDbTg5WqUF+Fakse/LAwv+/PZQreaz89tX",
+	"/pct5oaV1htbNRgQQ2mwTHc7UqQFOaJnHOIJETG7Bb55SzIiRShIodUepfp9QGfN6riue2j1Pr4xmuDv",
+	"9HLgbk2OEKFxWiTKb7Eeu9cy5FmMIhxncK3Rrmc47WbFpZtlmuuRa45wu/fym+ugX9TRf+kk9btFoyjF",
+	"C0ivM/zpOrXy7RrhFlMiN0h/gEzj3i4J7e6S0N26pKVG9rA4MQoGCfKab2dxQ3d9adYHDswswL+mxEYh",
+	"rfSXRUPp+9bFeyxBNwssDWWk9LpoL4s0/Mm7IlsAV0aJm3VltvZ3kGim5cAJS8LyMO/aXZ7r51uVTsQs",
+	"D+DOhXqsLaSmGGEV8QaBJBshUcRr5UdhitTUuiIDzW2UHmhkOVDSHWJ/xdteCXS61GWTHm8aF3J9nbEk",
+	"BBQXRW4j16oVUOli/bp9zXneEmsaRUvAsgju1Up/xDVBohx3sTEbc63uuw1IkmvtjfZPTHgxFmTbD94V",
+	"lJveC/1hiIq0NBt9HTUXnFIZLOE67TI65coSViP7WDVo5ErXArMwDpn2LALUnL67cLsK3cLurtGS8Yah",
+	"Gi49M41rBXLBMM6Ffo++N++DjoT9tlf8Kp7u2u3OMzt6iP6fGQ2x6kf12LfzmEMVvERLzrJd+NSEljrT",
+	"PBY4grx12JDqyEeC2uqp62Kp0kHA6tnZtrjWjgBUwghEuHKI25989/6t28F8mwNVPSQsLjIwW5kW+woe",
+	"8Fe+xAKQ19P8/KxPoXoJRyQBKsmSAA/00ZBXJShF18jMMcTX78uGLbZ+xTnjvgmosw3U66AmZSAEXkHg",
+	"XYNK00X1gUdgffAAde/gzguLdtmpd3BXi+t22ap/foRdB/YCG3JGJWcpulSvJ+gbvFGBJshyuUF3a6D+",
+	"Flud36lDEOXO10PyRCEnlYhQIQEnAw/5DEllJN6TTpD5ASF9ewv8lsBdtxbFKQEqr0P7uss1IPNaNCKL",
+	"w9fPVjDvXDcVYd68W/MJzLlkTCO62hFxqRSlFZvuiL38kzWlI2RtyYVke8h6hGKc5yHHogxUzEKOdNeu",
+	"UzNOT7tuabemVfhnvFsMoFsLlgZPJ/rl3asg9dDtdgVpxrG7clK6wtjzUPA6w5tm7BrNqVWclAiJFimL",
+	"b/R2yX05QndrkgJiGZHSKdGSQJogDkIyDsKTLEpgiYtUPjgmXsnpqO0P/bqA01ATVI+QtPvUQ0eQtyqF",
+	"Chl3aoLxw0RfxH+4c1pFtOtL59VvQyajkhsh6XjM7pNJFdYbIJqqcc+Jshgett5hH+HHY2vSOmwLaxUM",
+	"yX7txWFB6B2XH2hS5yrECKyKRNUiTzsSqsbbrli9obE/F2m6QR8LnCov2Q+MmX92G4eDXuNw/FtRcJ87",
+	"ISVva2+frr9Vwb4Baq7bdWu4jhm2v//ei4Y2Yu6HJt/K/TwYRTmWErj67H+v8Pjn6fj1hz/Yf4w//Kt7",
+	"9Mf/+N2v37N2sjJsCUmpxvcBAura+7QltOvu502KSda39wnnffZMaoDbbCNevTsqG0/zj0ftvkqsGZdB",
+	"Cmy/AQWpmijvPh7iq9uW3VqP05z2hj0D+c3D8b2W31zDqoM2VqkRA8FrxmXpo6mpKFcPqECM1k7tm0eI",
+	"Qc/8t+KDNQTcp4aa5gGaoNv9ajdrgzlTm+4AvmwHHceYLtDp4IyK2NS58/mzGoA6f4XFmrGbAQK3LbtF",
+	"DrfutkMjiUg/R5Ipj4moHS86NRsj/VBtr8yng2HAkqI7/tU75R0h0r9cXp5f6BipmbyOW+dMRxIka10+",
+	"ODx+uDY0ZDxAHS4kloXYQSnMB799OAjOP8Aw2+7UKHTgeoFjjFX5TcBSSqkmKvqObN3XyDVGGU6gI4Vg",
+	"a4K3VjTE4rjgfJdUTf3drmsyFBx27EJnpyOEU8Gc3DR5P4xPL06/GZeN1oAT4DUyu4LIKRbyugzMN+BH",
+	"PdbnQHqUjAmp9pVq4CUmKSSOucG1S+GTvLYNOlhrw32qpevKholyoCrHZDinhda7gax2jDLKGg5lG9FV",
+	"ylEOMar0z1sHjZ4HaP5FSXG//iMzru+5Wu5Eo8g20vQZmfQQdeEm0OJeTQV7QEszxafkznx4LUDxSueG",
+	"mH+avI/YbAcMoXYj6dqaQMF1rqNb9qH1LO2vGGP33FFwrSOLkNSeFTT01F1AsGbsmjPZvBRQm3Y3W7p9",
+	"l8qydybDQ8whxFP9HBVCGy0dtXESJyAmaL7QC7yEcstoe1UiYzZjvDsfskl7t0I6yN42ydJSdW4KS/oD",
+	"SUgeeFTtRojCHQiJloQLuasXUy62wJn3NpdKxbGV31AuoAe7UM2Rgz7Le1gRIdU4OnO4Jl7KSukSJVzX",
+	"crs1tpMc+Zxvq3dDvi1F0BkOccGJ3FxUSSo2k/a/Bt4AFHYPHi0Ac+DVKGsp8+heDULokjUuwEKGSRqd",
+	"uEd/SjZ0khCuyHLJl5H3rHlTKpoXkmU6kOh2xDrsSIQolLODaYIyTPFK/Ug2FGcqxTzdeFkO9ZyQlMRg",
+	"10B1dq609+yyIkj98BbaqenXOzT+Rg0JmRKw23KjPygL/cfIO3iMZpOp6oflQHFOopPoYKIe6djVWstg",
+	"T/1nFYKP9yALTk26lz1hLC8OlWdjZSLYWaLCsyDdYaSObBt10OPsT6dOMNYAeMGGvb/by7/wCWd5qplT",
+	"m4SZhlke2xZP6zhUa0bT3MSxPrZVr0SRZZhvqoPZcg7q7d7kDtJ0fEPZHd1LRJJtZZjNqSgTbUZlptcI",
+	"6bQUrTQ2nakn96vF2zI/4rOZ62fBVdE/68uP7T3aKoXtykSwxiQRNTsbfahlzgQCXlVGVygxeKavbdeS",
+	"d2fTZvbmycGr6dRZez9L9nAaSnQ9aMTjj5vJZVdlNuZsWqYj2kFsVmRk7sGP9VXz6H5UfnHU9YFx4cZ2",
+	"ktH9h2YS2VU0j0bRfD5X/7v84VIxp5n0ZTXdT+W6cplAGuTEyd7e7WxSwdWeXdkTLWNrGDqa1oDhdqZp",
+	"tGlbV1GtafRh8GJrp0LtvNrKLsxy0zwfGwGo0XMmZOddeextNLW9JcLeeNNejwJq3V+VvJqviVirBoyj",
+	"DKd32kATZfwWhUtorS88M5S+Ux8Z2whCfsmSzW5LrjRJkaboT/bVJGZZlc1yEh2+/mL5CmI8frX/xavx",
+	"4evXB+PFEhbjo4N4sVgs8Kvl9HhSl1ZVPSE6d7PL8QqQ0lmsT8IxWmB6g1K2InQyHEoDdRHu6w6C5AXc",
+	"fy4aEcXm43gWL/AhjA+WR/H4cHEMYzxbvh6/jl/F08VsMYV9/EDKuzXTqtHHAgrlDN2PosP917sRbze2",
+	"kWRsnGG6GVsNEV4K2Ul0yZhyEzZVnvca3wJaAFAdNTBb4A0reC2raPB865lpgZm2CXDBCrOT15J7D5Jv",
+	"xvOlBB7cTjCaCFRQSVKEKZNr4K678rYhjmPI7cX0ivRWwv99HQrccjZ4q2HAImknAthLYdWterXHWXFW",
+	"5L7DhS71FUBlmCHRQSoikFizO4oYTTeI0Rgm6EyWYSos1GLRDqbJEUKE/kS1SS/kmnHyszkeMVyz7p8O",
+	"jGnEIRTlKY7LuENsY2imK7ZESgRecIw2b6PVsad24+xz0Mf6lX9hGajTzeGKFbzz9mQg8CqZLg/wLB5/",
+	"kRzG40N89MV4cRS/Hn+Bp/EM7y+PYTazhT5OIuWMXf/4w3q9+OFL8eN/7wAOjcIggeVimyAbmvmHQIPV",
+	"Ah8a7PDPHB3cUnfOlYcPe/V7ZkG//C0RsllZpNqdFVSt495VaJVB9VPd+/9sH9yn/MorARLtT/cPxtOj",
+	"8XR2Ods/mU5PptMfo6bE3s0vPtttIMnAL6P7D7surkCBhJ5l5m+R1Uqbzh620gi9xSlJxv5Oxl9rc6Tf",
+	"104tdEDEQNljLqkz2hrqWToYfuxHK7of9bn6cP/BX+l6qeKWUpiEgfA+wAAMVlG+6ouq6s6Qhe3y6f3K",
+	"LdWlfE10s1qRzfWothn1Qy+TQFQ7SfUyT2NGl2RVqEidlzLiJG43z6NIL+yPhdnvWwMuXL7IMKm1bmyZ",
+	"jekDnYg2Aj0YFZ7Sk/hHgWJ5eB09xAkJ3lYIbp6dUnlFnzRGTh+GIw4bl0Wa1iHEO85d6zMAHK+VTyuF",
+	"r9rmguvjAcd3tIqB2VXFysvgSFH5YhOes01w6Ny2C12e4J45l+veO54Dz7CaS7qxJQZ83zBoO2xtOz+a",
+	"bwoscRAsvXVHATHWhy0poyvgaAE/UX04AMkILQqpjtXMGtKZJPoDu3W9wzzxB/x9e80hVSSvPFWiTNYo",
+	"/Yk6/qi3ZEWZPd8Jmjwzm4Y3+0CzoMseXg3DzA+7b9L8EoVPYS7KKnJPPYUBGO/VqHuBvOcLeUZjgo6w",
+	"Qjzh153cyS8OHStR9fP5+L4vnuWTeZZF5cmNS2HV/UteXsi0SkSEtmSNY1AiynPQp3Q0ny8SeVCjX9Zh",
+	"ogEze7+U/zxL7vfUee24PKPtxqALkM2aSPbWba1SRhuTwpdXBwBTs66pzmBOXMpuhTIDF6Fe6ipTwsOg",
+	"ihFR0xPxJfdZ9+4/a5MeQA57OfpAHVi7c/araLX8++sfPk4mk/er4yP6zQ5+Tf/l4qfw0crCp83g6JEN",
+	"jroJ7uCbhaqMBpbRm6byCpAWJg8+zztzaRd1hMw5uyUJJPbIyOKj/qJtbJ/CU5PmfsOzR8ZO+NqCjobe",
+	"sUk67fPQ1HKPdTaySk1o3ILHvHb7XYv+7Lys3qhTXFp4V6U5SIZyblLKT99d/EQ5LIhO6DVvdMFbREHe",
+	"MX5jT0mhLCdrSFeqpzeeJn1UrqEiztYo25KyFL5l/kAcL/IES3g+OF7WS6jXKq7KEX+Gh9h//f8pALx/",
+	"NsNRO1hXOLDETQurNMkLXj8TvHYoasG3F6hjjAejcwxckiUxYUiTByIJCJ36oUsf+y1EQO42D0UXTDDY",
+	"bmrGYWlid6JKCZEC0uXImYVUMKTT44S6GcE3porxAlJ2h1RM8AKoCiT6lVEks+n7Xv1r0YPObzB+weSB",
+	"oUJbueTKlSCf2vLgZSFsW/87SkEKoDHf5HLC+OrfXDCn4OTfXX6m3kjdTvcnKoOz8YF+qWLbcm+2f3Co",
+	"k1CbQ+o629WQKtFdshMXZaplGN4/lsXwSpIMtxOdZV4aAD39PICOMa4D1xqQP5gLm+vWJ7bKuuYeygoh",
+	"dYIKslwcISWlE3UUZcRlyrE/PnyX6/PFRD0LE+UpZL998tLsO82UrZ0CbWiGTxK4q3pvk+GbJbTY0n9r",
+	"jYbwy+cEE6IDlVseaD/sKM/IgtSuJlxFVMwmDqYp6AwVKvbrjx4Ludt1dh4K4FVPj4vfia9PdQir+IZ+",
+	"T8Xs9w7EMFo26itZUZqq+Y8PZf7CeEHsZ4HYFWR5CtoL3WV1qY7UdXVlGeF67X0lVuyHcjikgIVJLHCN",
+	"VEyHsEKkG2TvPasYjNoH+JWrBDLx0upqb60cg1KleI3pyj++a53OVbXnu7YNigBdQeklMj9oYVitiLLN",
+	"GOd59EiwXivM9aTpdIbuxrHmgwnuXpm6gdPvx7EsqVXTOsrqp3Vw1Td7EQf3B9MeH1ltbbnnYTumD7Qd",
+	"mkljiUPzM1Jb67+CyQEnG3tVwtOXR5qQUUQ3Sk0hn7VRNParkfHRbxFt1Y1umzhPboFLItQW3NwFowlS",
+	"FeWqQnSIUGQKM6l3F9+/+bLc2Q88KfHibPbMwzZycUMi7B8fgsT+pRP3Zk2oi7ZRVlFImUefZChOAZsl",
+	"pj7oi7rZqm8vkbdhF9Z15cIr/8+qmwvCx7P9g0eypI06fA/dHdlujAK8nEA8t82CL/1+TCzLrnVecdc3",
+	"SYPXSAOnC25vYBsJyXKB1Pmu2kKQLIOEYAnpZqSBC/9E/1YrWvQ3W4HMXYS1R762bEwPkL3X319a1dwV",
+	"zJ51Ys7uGFWriPkU3r6jcpF9f3D29feH//PX2d3i6+8eTmrfolJ6atXvBSCfQ7KhlrVhRz82WtjpQ0dT",
+	"wwphXabTFf8wd/hNhfoE/efFt+8srKlQiDpAbbhSJg4iJugrHK9tYSwDcrZqgDAp1D9R1aX5U9oxB2lA",
+	"VPWlRifCB1hHhFe4zhAxQd9mRJZf1c9ptwOtrbX14jEOWzi2OtuVXzKwXeQvHF1vVtLxjlL3lAyEqQS1",
+	"Oyg2qr0+BYK7coDRwcf98Rd3quTQfDilzZp+gbVumzxu7P+uVO46dntl67yj24Kn5si2EGDOZ58Ctssl",
+	"+WKanoXv7nRwiGXaq6rBhg3U1yBt/k1ZltHpsjMdrJAxMzVqiSIrWL9yu0Eoa66++N5P6nsHy08/ScTd",
+	"K3J65deino22lwmx9aB9q6cvHk0Xr5YHqr7WfnwM48Pk6PX4NT48GM+Wr5bT5fHycDmDqF6sOSoofMoh",
+	"1hdm9KxRzBI4QUdTFYapV19u07Ov6XHrpKwofP/BEjnIOD+uHQ6XK+2xca4w8osF+H9vAb6uLIAVu+mr",
+	"Stf4pa++oqqR838DAHffSeFZkgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
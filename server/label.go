@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	SchemeUUID  = "uuid"
+	SchemeShort = "short"
+
+	shortLabelBytes    = 5
+	shortLabelAttempts = 10
+	labelMinLength     = 3
+	labelMaxLength     = 40
+)
+
+var (
+	errLabelExhausted = errors.New("unable to allocate unique short label")
+
+	labelEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+	labelPattern  = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+)
+
+func allocateShortLabel(ctx context.Context, store Store, id uuid.UUID) (string, error) {
+	buf := make([]byte, shortLabelBytes)
+
+	for i := 0; i < shortLabelAttempts; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+
+		label := labelEncoding.EncodeToString(buf)
+
+		ok, err := store.ClaimSubdomainLabel(ctx, label, id)
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			return label, nil
+		}
+	}
+
+	return "", errLabelExhausted
+}
+
+func validVanityLabel(label string, reserved map[string]struct{}) bool {
+	if len(label) < labelMinLength || len(label) > labelMaxLength || !labelPattern.MatchString(label) {
+		return false
+	}
+
+	// Avoid labels that could be confused with UUIDs or IP encoded names.
+	if _, err := uuid.Parse(label); err == nil {
+		return false
+	}
+
+	if strings.HasSuffix(label, "-v4") || strings.HasSuffix(label, "-v6") {
+		return false
+	}
+
+	_, isReserved := reserved[label]
+
+	return !isReserved
+}
+
+func resolveSubdomain(ctx context.Context, store Store, label string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(label); err == nil {
+		return id, nil
+	}
+
+	if !labelPattern.MatchString(label) {
+		return uuid.Nil, nil
+	}
+
+	return store.GetSubdomainLabel(ctx, label)
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ClassLoopback  = "loopback"
+	ClassPrivate   = "private"
+	ClassLinkLocal = "link-local"
+	ClassULA       = "ula"
+	ClassPublic    = "public"
+)
+
+var addressClasses = []string{ClassLoopback, ClassPrivate, ClassLinkLocal, ClassULA, ClassPublic}
+
+func classifyAddress(ip net.IP) string {
+	switch {
+	// 0.0.0.0 and :: reach the local host on most platforms
+	case ip.IsLoopback(), ip.IsUnspecified():
+		return ClassLoopback
+	case ip.IsLinkLocalUnicast():
+		return ClassLinkLocal
+	case ip.IsPrivate() && ip.To4() != nil:
+		return ClassPrivate
+	case ip.IsPrivate():
+		return ClassULA
+	default:
+		return ClassPublic
+	}
+}
+
+func validAddressClass(class string) bool {
+	for _, c := range addressClasses {
+		if c == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) effectiveClasses(ctx context.Context, id uuid.UUID) ([]string, error) {
+	owner, err := s.store.GetAddressPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return intersectClasses(s.addressClasses, owner), nil
+}
+
+func (s *Server) synthesisAllowed(ctx context.Context, id uuid.UUID, ip net.IP) (bool, error) {
+	forbidden, err := s.addressForbidden(ctx, ip)
+	if err != nil || forbidden {
+		return false, err
+	}
+
+	classes, err := s.effectiveClasses(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	class := classifyAddress(ip)
+
+	for _, c := range classes {
+		if c == class {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func intersectClasses(server map[string]struct{}, owner []string) []string {
+	res := []string{}
+
+	if owner == nil {
+		owner = addressClasses
+	}
+
+	for _, c := range owner {
+		if _, ok := server[c]; ok {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
@@ -3,9 +3,11 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"net"
+	"sync/atomic"
 
-	"github.com/miekg/dns"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"golang.org/x/sync/errgroup"
 )
@@ -13,25 +15,75 @@ import (
 const Version = "1.0.0"
 
 type Server struct {
-	logger *zap.SugaredLogger
-	cfg    Config
-	acm    *autocert.Manager
-	store  Store
+	logger         *zap.SugaredLogger
+	cfg            Config
+	acm            *autocert.Manager
+	certs          *certManager
+	zoneChallenges map[string]struct{}
+	zone           atomic.Pointer[staticZone]
+	store          Store
+	webhooks       *webhookSender
+	forbiddenNets  []*net.IPNet
+	addressClasses map[string]struct{}
 }
 
 func New(logger *zap.SugaredLogger, cfg Config, store Store) *Server {
 	s := &Server{
-		logger: logger,
-		cfg:    cfg,
-		store:  store,
+		logger:         logger,
+		cfg:            cfg,
+		store:          store,
+		webhooks:       newWebhookSender(logger, store, cfg.WebhookAllowPrivate),
+		addressClasses: map[string]struct{}{},
+	}
+
+	classes := cfg.AddressClasses
+	if len(classes) == 0 {
+		classes = addressClasses
+	}
+
+	for _, class := range classes {
+		if validAddressClass(class) {
+			s.addressClasses[class] = struct{}{}
+		} else {
+			logger.Warnw("Ignoring invalid address class", "class", class)
+		}
+	}
+
+	for _, cidr := range cfg.ForbiddenCIDRs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logger.Warnw("Ignoring invalid forbidden CIDR", "cidr", cidr, "err", err)
+
+			continue
+		}
+
+		s.forbiddenNets = append(s.forbiddenNets, n)
+	}
+
+	s.zone.Store(s.loadStaticZone(cfg, nil))
+
+	if s.cfg.CertCacheDir == "" {
+		s.cfg.CertCacheDir = "cache"
 	}
 
 	if cfg.ACMEEnabled {
-		s.acm = &autocert.Manager{
-			Prompt:     autocert.AcceptTOS,
-			HostPolicy: autocert.HostWhitelist(s.cfg.APIHost),
-			Cache:      autocert.DirCache("cache"),
-			Email:      s.cfg.ACMEContact,
+		switch cfg.ACMEChallenge {
+		case "", ChallengeHTTP01:
+			s.acm = &autocert.Manager{
+				Prompt:     autocert.AcceptTOS,
+				HostPolicy: autocert.HostWhitelist(s.cfg.APIHost),
+				Cache:      autocert.DirCache(s.cfg.CertCacheDir),
+				Email:      s.cfg.ACMEContact,
+			}
+
+			if cfg.ACMEDirectory != "" {
+				s.acm.Client = &acme.Client{DirectoryURL: cfg.ACMEDirectory}
+			}
+		case ChallengeDNS01:
+			s.certs = newCertManager(s)
+			s.zoneChallenges = s.certs.challengeNames()
+		default:
+			logger.Warnw("Unsupported ACME challenge, ACME disabled", "challenge", cfg.ACMEChallenge)
 		}
 	}
 
@@ -44,31 +96,86 @@ func (s *Server) Start() error {
 		return err
 	}
 
-	group, _ := errgroup.WithContext(context.Background())
+	group, ctx := errgroup.WithContext(context.Background())
+
+	group.Go(s.newDNSServer(":53", "udp").ListenAndServe)
+	group.Go(s.newDNSServer(":53", "tcp").ListenAndServe)
 
 	group.Go(func() error {
-		return dns.ListenAndServe(":53", "udp", s)
+		s.webhooks.run(ctx)
+
+		return nil
 	})
 
+	if s.certs != nil {
+		group.Go(func() error {
+			s.certs.run(ctx)
+
+			return nil
+		})
+	}
+
+	var tlsConfig *tls.Config
+
+	if s.cfg.APIListenHTTPS != "" || s.cfg.DoTListen != "" {
+		tlsConfig, err = s.buildTLSConfig()
+		if err != nil {
+			return err
+		}
+	}
+
 	if s.cfg.APIListenHTTPS != "" {
 		rs := s.buildHTTPRedirectServer()
 		group.Go(rs.ListenAndServe)
 
 		hs.Addr = s.cfg.APIListenHTTPS
-		hs.TLSConfig = &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		}
-
-		if s.cfg.ACMEEnabled {
-			hs.TLSConfig.GetCertificate = s.acm.GetCertificate
-		}
+		hs.TLSConfig = tlsConfig
 
 		group.Go(func() error {
-			return hs.ListenAndServeTLS(s.cfg.CertFile, s.cfg.KeyFile)
+			return hs.ListenAndServeTLS("", "")
 		})
 	} else {
 		group.Go(hs.ListenAndServe)
 	}
 
+	if s.cfg.DoTListen != "" {
+		dot := s.newDNSServer(s.cfg.DoTListen, "tcp-tls")
+		dot.TLSConfig = tlsConfig
+
+		group.Go(dot.ListenAndServe)
+	}
+
+	if s.cfg.AdminListen != "" {
+		if s.cfg.AdminToken == "" {
+			s.logger.Warnw("Admin API disabled, no admin token configured")
+		} else {
+			as := s.buildAdminServer()
+			group.Go(as.ListenAndServe)
+		}
+	}
+
 	return group.Wait()
 }
+
+func (s *Server) buildTLSConfig() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if s.cfg.CertFile != "" && s.cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if s.acm != nil {
+		cfg.GetCertificate = s.acm.GetCertificate
+	} else if s.certs != nil {
+		cfg.GetCertificate = s.certs.GetCertificate
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/miekg/dns"
+)
+
+type ServiceHints struct {
+	Port uint16   `json:"port,omitempty"`
+	ALPN []string `json:"alpn,omitempty"`
+}
+
+// serviceRecord synthesizes a HTTPS or SVCB answer for an IP encoded name,
+// returning nil when the owner has not configured any hints.
+func (s *Server) serviceRecord(ctx context.Context, q dns.Question, id uuid.UUID, ip net.IP) (dns.RR, error) {
+	hints, err := s.store.GetServiceHints(ctx, id)
+	if err != nil || hints == nil {
+		return nil, err
+	}
+
+	svcb := dns.SVCB{
+		Hdr:      dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: q.Qclass, Ttl: 0},
+		Priority: 1,
+		Target:   ".",
+	}
+
+	if len(hints.ALPN) > 0 {
+		svcb.Value = append(svcb.Value, &dns.SVCBAlpn{Alpn: hints.ALPN})
+	}
+
+	if hints.Port != 0 {
+		svcb.Value = append(svcb.Value, &dns.SVCBPort{Port: hints.Port})
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		svcb.Value = append(svcb.Value, &dns.SVCBIPv4Hint{Hint: []net.IP{v4}})
+	} else {
+		svcb.Value = append(svcb.Value, &dns.SVCBIPv6Hint{Hint: []net.IP{ip}})
+	}
+
+	if q.Qtype == dns.TypeHTTPS {
+		return &dns.HTTPS{SVCB: svcb}, nil
+	}
+
+	return &svcb, nil
+}
+
+func decodeAddress(reqType string, reqValue string) net.IP {
+	if reqType == "v4" {
+		return net.ParseIP(strings.ReplaceAll(reqValue, "-", ".")).To4()
+	}
+
+	return net.ParseIP(strings.ReplaceAll(reqValue, "-", ":")).To16()
+}
@@ -2,11 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -16,120 +19,1942 @@ import (
 	"go.uber.org/zap"
 )
 
+var errInvalidRedisCA = errors.New("no certificates found in redis CA file")
+
 type Store interface {
-	SetACMEChallengeTokens(ctx context.Context, id uuid.UUID, tokens []string) error
+	SetACMEChallengeTokens(ctx context.Context, id uuid.UUID, tokens []string, ttl time.Duration) error
+
+	// GetACMEChallengeTokens returns the tokens and when they expire, which is
+	// zero when no tokens are set.
+	GetACMEChallengeTokens(ctx context.Context, id uuid.UUID) ([]string, time.Time, error)
+
+	SetZoneChallengeTokens(ctx context.Context, name string, tokens []string, ttl time.Duration) error
+
+	GetZoneChallengeTokens(ctx context.Context, name string) ([]string, time.Time, error)
+
+	ClaimSubdomainLabel(ctx context.Context, label string, id uuid.UUID) (bool, error)
+
+	GetSubdomainLabel(ctx context.Context, label string) (uuid.UUID, error)
+
+	// ClaimVanityLabel claims label for id until ttl passes, releasing the
+	// vanity label previously claimed by id, which is returned.
+	ClaimVanityLabel(ctx context.Context, label string, id uuid.UUID, ttl time.Duration) (bool, string, error)
+
+	// RefreshVanityLabel extends the claim of the vanity label of id, if any.
+	RefreshVanityLabel(ctx context.Context, id uuid.UUID, ttl time.Duration) error
+
+	SetDelegation(ctx context.Context, id uuid.UUID, delegation *Delegation) error
+
+	GetDelegation(ctx context.Context, id uuid.UUID) (*Delegation, error)
+
+	SetAddressPolicy(ctx context.Context, id uuid.UUID, classes []string) error
+
+	GetAddressPolicy(ctx context.Context, id uuid.UUID) ([]string, error)
+
+	SetServiceHints(ctx context.Context, id uuid.UUID, hints *ServiceHints) error
+
+	GetServiceHints(ctx context.Context, id uuid.UUID) (*ServiceHints, error)
+
+	SetCAARecords(ctx context.Context, id uuid.UUID, records []CAARecord) error
+
+	GetCAARecords(ctx context.Context, id uuid.UUID) ([]CAARecord, error)
+
+	BlockSubdomain(ctx context.Context, block SubdomainBlock) error
+
+	UnblockSubdomain(ctx context.Context, id uuid.UUID) error
+
+	GetSubdomainBlock(ctx context.Context, id uuid.UUID) (*SubdomainBlock, error)
+
+	ListSubdomainBlocks(ctx context.Context) ([]SubdomainBlock, error)
+
+	// RevokeSubdomain permanently revokes a subdomain, independent of any
+	// admin block.
+	RevokeSubdomain(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// GetSubdomainRevocation returns nil when the subdomain is not revoked.
+	GetSubdomainRevocation(ctx context.Context, id uuid.UUID) (*time.Time, error)
+
+	// RotateSubdomainToken advances the token generation of a subdomain,
+	// returning the new generation.
+	RotateSubdomainToken(ctx context.Context, id uuid.UUID) (int64, error)
+
+	// GetSubdomainTokenGeneration returns 0 when the token was never rotated.
+	GetSubdomainTokenGeneration(ctx context.Context, id uuid.UUID) (int64, error)
+
+	SetForbiddenCIDRs(ctx context.Context, cidrs []string) error
+
+	GetForbiddenCIDRs(ctx context.Context) ([]string, error)
+
+	CreateAccount(ctx context.Context, account Account) error
+
+	GetAccount(ctx context.Context, keyHash string) (*Account, error)
+
+	// AddAccountSubdomain adds sub unless the account already holds limit
+	// subdomains, revoked ones included.
+	AddAccountSubdomain(ctx context.Context, accountID uuid.UUID, sub AccountSubdomain, limit int) (bool, error)
+
+	SetAccountSubdomain(ctx context.Context, accountID uuid.UUID, sub AccountSubdomain) error
+
+	// RemoveAccountSubdomain releases a slot reserved by AddAccountSubdomain.
+	RemoveAccountSubdomain(ctx context.Context, accountID uuid.UUID, id uuid.UUID) error
+
+	// GetSubdomainAccount returns uuid.Nil when the subdomain has no account.
+	GetSubdomainAccount(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+
+	ListAccountSubdomains(ctx context.Context, accountID uuid.UUID) ([]AccountSubdomain, error)
+
+	SetWebhook(ctx context.Context, id uuid.UUID, hook *Webhook) error
+
+	GetWebhook(ctx context.Context, id uuid.UUID) (*Webhook, error)
+
+	AddWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+
+	UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+
+	// ClaimWebhookDeliveries returns pending deliveries due by now, hiding them
+	// from other claims for the lease duration.
+	ClaimWebhookDeliveries(
+		ctx context.Context,
+		now time.Time,
+		lease time.Duration,
+		limit int,
+	) ([]*WebhookDelivery, error)
+
+	ListWebhookDeliveries(ctx context.Context, id uuid.UUID) ([]*WebhookDelivery, error)
+
+	RecordActivity(ctx context.Context, id uuid.UUID, activity Activity) error
+
+	GetActivity(ctx context.Context, id uuid.UUID) ([]Activity, error)
+
+	ListActiveSubdomains(ctx context.Context, limit int) ([]uuid.UUID, error)
+
+	// AddAbuseReport stores a report, evicting reports past the retention
+	// period and the oldest reports beyond the configured maximum.
+	AddAbuseReport(ctx context.Context, report AbuseReport) error
+
+	ListAbuseReports(ctx context.Context) ([]AbuseReport, error)
+
+	DeleteAbuseReport(ctx context.Context, id uuid.UUID) error
+
+	IncrementStat(ctx context.Context, key string, value int64)
+
+	// IncrementRate counts an event under key, returning the number of events
+	// counted in the current window and the time until it resets.
+	IncrementRate(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+
+	// ZoneSerial returns the serial for the static zone with the given content
+	// hash, advancing it only when the hash differs from the last one seen.
+	ZoneSerial(ctx context.Context, hash string, initial uint32) (uint32, error)
+}
+
+type RedisStore struct {
+	rdb        redis.UniversalClient
+	prefix     string
+	maxEntries int
+	maxReports int
+	retention  time.Duration
+}
+
+func NewRedisStore(logger *zap.SugaredLogger, cfg Config) (*RedisStore, error) {
+	addrs := cfg.RedisAddrs
+	if len(addrs) == 0 {
+		addrs = []string{cfg.RedisAddr}
+	}
+
+	opts := &redis.UniversalOptions{
+		Addrs:                 addrs,
+		DB:                    cfg.RedisDB,
+		Username:              cfg.RedisUser,
+		Password:              cfg.RedisPass,
+		SentinelUsername:      cfg.RedisSentinelUser,
+		SentinelPassword:      cfg.RedisSentinelPass,
+		MasterName:            cfg.RedisMasterName,
+		ContextTimeoutEnabled: true,
+	}
+
+	if cfg.RedisTLS {
+		tlsConfig, err := redisTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.TLSConfig = tlsConfig
+	}
+
+	var rdb redis.UniversalClient
+
+	// The universal client only selects cluster mode for multiple addresses
+	if cfg.RedisCluster {
+		rdb = redis.NewClusterClient(opts.Cluster())
+	} else {
+		rdb = redis.NewUniversalClient(opts)
+	}
+
+	rdb.AddHook(newCircuitBreaker(logger, cfg))
+
+	return &RedisStore{
+		rdb:        rdb,
+		prefix:     cfg.RedisKeyPrefix,
+		maxEntries: cfg.challengeMaxEntries(),
+		maxReports: cfg.abuseReportsMax(),
+		retention:  cfg.abuseRetention(),
+	}, nil
+}
+
+func redisTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.RedisTLSServerName,
+	}
+
+	if cfg.RedisTLSCA != "" {
+		ca, err := os.ReadFile(cfg.RedisTLSCA)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errInvalidRedisCA
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func (s *RedisStore) key(format string, args ...any) string {
+	return s.prefix + fmt.Sprintf(format, args...)
+}
+
+func (s *RedisStore) SetACMEChallengeTokens(ctx context.Context, id uuid.UUID, tokens []string, ttl time.Duration) error {
+	val, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	var count *redis.IntCmd
+
+	// Challenges are tracked by expiry so the oldest can be evicted over the limit
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.key("%s-acme-challenge", id), string(val), ttl)
+		pipe.ZAdd(ctx, s.key("challenges"), redis.Z{Score: float64(now.Add(ttl).Unix()), Member: id.String()})
+		pipe.ZRemRangeByScore(ctx, s.key("challenges"), "-inf", strconv.FormatInt(now.Unix(), 10))
+		count = pipe.ZCard(ctx, s.key("challenges"))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	excess := count.Val() - int64(s.maxEntries)
+	if excess <= 0 {
+		return nil
+	}
+
+	evicted, err := s.rdb.ZPopMin(ctx, s.key("challenges"), excess).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(evicted))
+
+	for _, z := range evicted {
+		keys = append(keys, s.key("%s-acme-challenge", z.Member))
+	}
+
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, key)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) getChallengeTokens(ctx context.Context, key string) ([]string, time.Time, error) {
+	var (
+		get *redis.StringCmd
+		ttl *redis.DurationCmd
+	)
+
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		ttl = pipe.PTTL(ctx, key)
+
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return nil, time.Time{}, nil
+	} else if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var res []string
+
+	if err := json.Unmarshal([]byte(get.Val()), &res); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var expires time.Time
+
+	if ttl.Val() > 0 {
+		expires = time.Now().Add(ttl.Val())
+	}
+
+	return res, expires, nil
+}
+
+func (s *RedisStore) GetACMEChallengeTokens(ctx context.Context, id uuid.UUID) ([]string, time.Time, error) {
+	return s.getChallengeTokens(ctx, s.key("%s-acme-challenge", id))
+}
+
+func (s *RedisStore) SetZoneChallengeTokens(ctx context.Context, name string, tokens []string, ttl time.Duration) error {
+	if len(tokens) == 0 {
+		return s.rdb.Del(ctx, s.key("%s-zone-challenge", name)).Err()
+	}
+
+	val, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, s.key("%s-zone-challenge", name), string(val), ttl).Err()
+}
+
+func (s *RedisStore) GetZoneChallengeTokens(ctx context.Context, name string) ([]string, time.Time, error) {
+	return s.getChallengeTokens(ctx, s.key("%s-zone-challenge", name))
+}
+
+func (s *RedisStore) ClaimSubdomainLabel(ctx context.Context, label string, id uuid.UUID) (bool, error) {
+	return s.rdb.SetNX(ctx, s.key("%s-label", label), id.String(), 0).Result()
+}
+
+func (s *RedisStore) GetSubdomainLabel(ctx context.Context, label string) (uuid.UUID, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-label", label)).Result()
+	if errors.Is(err, redis.Nil) {
+		return uuid.Nil, nil
+	} else if err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(val)
+}
+
+// claimLabelScript claims a free label or extends the claim of its owner.
+// Labels without an expiry are permanent short labels and are never claimed.
+var claimLabelScript = redis.NewScript(`
+local owner = redis.call("GET", KEYS[1])
+if owner and redis.call("PTTL", KEYS[1]) < 0 then
+	return 0
+end
+if owner == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+if owner then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1
+`)
+
+// releaseLabelScript deletes a vanity label only if it is still owned by the
+// caller.
+var releaseLabelScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] and redis.call("PTTL", KEYS[1]) > 0 then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (s *RedisStore) ClaimVanityLabel(
+	ctx context.Context,
+	label string,
+	id uuid.UUID,
+	ttl time.Duration,
+) (bool, string, error) {
+	labelKey := []string{s.key("%s-label", label)}
+
+	claimed, err := claimLabelScript.Run(ctx, s.rdb, labelKey, id.String(), ttl.Milliseconds()).Int()
+	if err != nil || claimed == 0 {
+		return false, "", err
+	}
+
+	// Concurrent claims by one subdomain are ordered by the swap, so only the
+	// last label remains claimed
+	prev, err := s.rdb.SetArgs(ctx, s.key("%s-vanity", id), label, redis.SetArgs{Get: true, TTL: ttl}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, "", err
+	}
+
+	if prev == "" || prev == label {
+		return true, "", nil
+	}
+
+	if err := releaseLabelScript.Run(ctx, s.rdb, []string{s.key("%s-label", prev)}, id.String()).Err(); err != nil {
+		return false, "", err
+	}
+
+	return true, prev, nil
+}
+
+func (s *RedisStore) RefreshVanityLabel(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	label, err := s.rdb.Get(ctx, s.key("%s-vanity", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	labelKey := []string{s.key("%s-label", label)}
+
+	if err := claimLabelScript.Run(ctx, s.rdb, labelKey, id.String(), ttl.Milliseconds()).Err(); err != nil {
+		return err
+	}
+
+	return s.rdb.PExpire(ctx, s.key("%s-vanity", id), ttl).Err()
+}
+
+func (s *RedisStore) SetDelegation(ctx context.Context, id uuid.UUID, delegation *Delegation) error {
+	key := s.key("%s-delegation", id)
+
+	if delegation == nil {
+		return s.rdb.Del(ctx, key).Err()
+	}
+
+	val, err := json.Marshal(delegation)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, key, string(val), 0).Err()
+}
+
+func (s *RedisStore) GetDelegation(ctx context.Context, id uuid.UUID) (*Delegation, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-delegation", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res Delegation
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *RedisStore) SetAddressPolicy(ctx context.Context, id uuid.UUID, classes []string) error {
+	if classes == nil {
+		return s.rdb.Del(ctx, s.key("%s-address-policy", id)).Err()
+	}
+
+	val, err := json.Marshal(classes)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, s.key("%s-address-policy", id), string(val), 0).Err()
+}
+
+func (s *RedisStore) GetAddressPolicy(ctx context.Context, id uuid.UUID) ([]string, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-address-policy", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) SetServiceHints(ctx context.Context, id uuid.UUID, hints *ServiceHints) error {
+	key := s.key("%s-service", id)
+
+	if hints == nil {
+		return s.rdb.Del(ctx, key).Err()
+	}
+
+	val, err := json.Marshal(hints)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, key, string(val), 0).Err()
+}
+
+func (s *RedisStore) GetServiceHints(ctx context.Context, id uuid.UUID) (*ServiceHints, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-service", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res ServiceHints
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *RedisStore) SetCAARecords(ctx context.Context, id uuid.UUID, records []CAARecord) error {
+	key := s.key("%s-caa", id)
+
+	if len(records) == 0 {
+		return s.rdb.Del(ctx, key).Err()
+	}
+
+	val, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, key, string(val), 0).Err()
+}
+
+func (s *RedisStore) GetCAARecords(ctx context.Context, id uuid.UUID) ([]CAARecord, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-caa", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res []CAARecord
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) BlockSubdomain(ctx context.Context, block SubdomainBlock) error {
+	val, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.key("%s-block", block.ID), string(val), 0)
+		pipe.SAdd(ctx, s.key("blocks"), block.ID.String())
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) UnblockSubdomain(ctx context.Context, id uuid.UUID) error {
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, s.key("%s-block", id))
+		pipe.SRem(ctx, s.key("blocks"), id.String())
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) GetSubdomainBlock(ctx context.Context, id uuid.UUID) (*SubdomainBlock, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-block", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res SubdomainBlock
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *RedisStore) ListSubdomainBlocks(ctx context.Context) ([]SubdomainBlock, error) {
+	ids, err := s.rdb.SMembers(ctx, s.key("blocks")).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]SubdomainBlock, 0, len(ids))
+
+	for _, raw := range ids {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			continue
+		}
+
+		block, err := s.GetSubdomainBlock(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if block != nil {
+			res = append(res, *block)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) RevokeSubdomain(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return s.rdb.Set(ctx, s.key("%s-revoked", id), at.Format(time.RFC3339Nano), 0).Err()
+}
+
+func (s *RedisStore) GetSubdomainRevocation(ctx context.Context, id uuid.UUID) (*time.Time, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-revoked", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	at, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &at, nil
+}
+
+func (s *RedisStore) RotateSubdomainToken(ctx context.Context, id uuid.UUID) (int64, error) {
+	return s.rdb.Incr(ctx, s.key("%s-token-gen", id)).Result()
+}
+
+func (s *RedisStore) GetSubdomainTokenGeneration(ctx context.Context, id uuid.UUID) (int64, error) {
+	gen, err := s.rdb.Get(ctx, s.key("%s-token-gen", id)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+
+	return gen, err
+}
+
+func (s *RedisStore) SetForbiddenCIDRs(ctx context.Context, cidrs []string) error {
+	val, err := json.Marshal(cidrs)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, s.key("forbidden-cidrs"), string(val), 0).Err()
+}
+
+func (s *RedisStore) GetForbiddenCIDRs(ctx context.Context) ([]string, error) {
+	var res []string
+
+	val, err := s.rdb.Get(ctx, s.key("forbidden-cidrs")).Result()
+	if errors.Is(err, redis.Nil) {
+		return res, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) CreateAccount(ctx context.Context, account Account) error {
+	val, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, s.key("account-%s", account.KeyHash), string(val), 0).Err()
+}
+
+func (s *RedisStore) GetAccount(ctx context.Context, keyHash string) (*Account, error) {
+	val, err := s.rdb.Get(ctx, s.key("account-%s", keyHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res Account
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+var addAccountSubdomainScript = redis.NewScript(`
+if redis.call("HLEN", KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
+func (s *RedisStore) AddAccountSubdomain(
+	ctx context.Context,
+	accountID uuid.UUID,
+	sub AccountSubdomain,
+	limit int,
+) (bool, error) {
+	val, err := json.Marshal(sub)
+	if err != nil {
+		return false, err
+	}
+
+	added, err := addAccountSubdomainScript.Run(
+		ctx,
+		s.rdb,
+		[]string{s.key("%s-account-subdomains", accountID)},
+		sub.ID.String(),
+		string(val),
+		limit,
+	).Int()
+	if err != nil || added == 0 {
+		return false, err
+	}
+
+	return true, s.rdb.Set(ctx, s.key("%s-account", sub.ID), accountID.String(), 0).Err()
+}
+
+func (s *RedisStore) SetAccountSubdomain(ctx context.Context, accountID uuid.UUID, sub AccountSubdomain) error {
+	val, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.key("%s-account-subdomains", accountID), sub.ID.String(), string(val))
+		pipe.Set(ctx, s.key("%s-account", sub.ID), accountID.String(), 0)
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) RemoveAccountSubdomain(ctx context.Context, accountID uuid.UUID, id uuid.UUID) error {
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, s.key("%s-account-subdomains", accountID), id.String())
+		pipe.Del(ctx, s.key("%s-account", id))
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) GetSubdomainAccount(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-account", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return uuid.Nil, nil
+	} else if err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(val)
+}
+
+func (s *RedisStore) ListAccountSubdomains(ctx context.Context, accountID uuid.UUID) ([]AccountSubdomain, error) {
+	vals, err := s.rdb.HVals(ctx, s.key("%s-account-subdomains", accountID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]AccountSubdomain, 0, len(vals))
+
+	for _, val := range vals {
+		var sub AccountSubdomain
+
+		if err := json.Unmarshal([]byte(val), &sub); err != nil {
+			return nil, err
+		}
+
+		res = append(res, sub)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Created.Before(res[j].Created)
+	})
+
+	return res, nil
+}
+
+func (s *RedisStore) SetWebhook(ctx context.Context, id uuid.UUID, hook *Webhook) error {
+	key := s.key("%s-webhook", id)
+
+	if hook == nil {
+		return s.rdb.Del(ctx, key).Err()
+	}
+
+	val, err := json.Marshal(hook)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Set(ctx, key, string(val), 0).Err()
+}
+
+func (s *RedisStore) GetWebhook(ctx context.Context, id uuid.UUID) (*Webhook, error) {
+	val, err := s.rdb.Get(ctx, s.key("%s-webhook", id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res Webhook
+
+	if err := json.Unmarshal([]byte(val), &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *RedisStore) AddWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	val, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	history := s.key("%s-webhook-deliveries", delivery.SubdomainID)
+
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.key("webhook-delivery-%s", delivery.ID), string(val), webhookRetention)
+		pipe.ZAdd(ctx, s.key("webhook-queue"), redis.Z{
+			Score:  float64(delivery.NextAttempt.Unix()),
+			Member: delivery.ID.String(),
+		})
+		pipe.LPush(ctx, history, delivery.ID.String())
+		pipe.LTrim(ctx, history, 0, webhookHistoryLimit-1)
+		pipe.Expire(ctx, history, webhookRetention)
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	val, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.key("webhook-delivery-%s", delivery.ID), string(val), webhookRetention)
+
+		if delivery.Status == WebhookPending {
+			pipe.ZAdd(ctx, s.key("webhook-queue"), redis.Z{
+				Score:  float64(delivery.NextAttempt.Unix()),
+				Member: delivery.ID.String(),
+			})
+		} else {
+			pipe.ZRem(ctx, s.key("webhook-queue"), delivery.ID.String())
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// claimWebhookScript moves a due delivery to the end of its lease, so only one
+// node can claim it.
+var claimWebhookScript = redis.NewScript(`
+local score = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if score and tonumber(score) <= tonumber(ARGV[2]) then
+	redis.call("ZADD", KEYS[1], ARGV[3], ARGV[1])
+	return 1
+end
+return 0
+`)
+
+func (s *RedisStore) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]*WebhookDelivery, error) {
+	queue := s.key("webhook-queue")
+	due := strconv.FormatInt(now.Unix(), 10)
+
+	ids, err := s.rdb.ZRangeByScore(ctx, queue, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   due,
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*WebhookDelivery, 0, len(ids))
+
+	for _, id := range ids {
+		claimed, err := claimWebhookScript.Run(ctx, s.rdb, []string{queue}, id, due, now.Add(lease).Unix()).Int()
+		if err != nil {
+			return res, err
+		}
+
+		if claimed == 0 {
+			continue
+		}
+
+		val, err := s.rdb.Get(ctx, s.key("webhook-delivery-%s", id)).Result()
+		if errors.Is(err, redis.Nil) {
+			s.rdb.ZRem(ctx, queue, id)
+
+			continue
+		} else if err != nil {
+			return res, err
+		}
+
+		var delivery WebhookDelivery
+
+		if err := json.Unmarshal([]byte(val), &delivery); err != nil {
+			return res, err
+		}
+
+		res = append(res, &delivery)
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) ListWebhookDeliveries(ctx context.Context, id uuid.UUID) ([]*WebhookDelivery, error) {
+	ids, err := s.rdb.LRange(ctx, s.key("%s-webhook-deliveries", id), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*WebhookDelivery, 0, len(ids))
+
+	for _, deliveryID := range ids {
+		val, err := s.rdb.Get(ctx, s.key("webhook-delivery-%s", deliveryID)).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		var delivery WebhookDelivery
+
+		if err := json.Unmarshal([]byte(val), &delivery); err != nil {
+			return nil, err
+		}
+
+		res = append(res, &delivery)
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) RecordActivity(ctx context.Context, id uuid.UUID, activity Activity) error {
+	val, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+
+	key := s.key("%s-activity", id)
+
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, string(val))
+		pipe.LTrim(ctx, key, 0, activityLimit-1)
+		pipe.ZAdd(ctx, s.key("activity"), redis.Z{Score: float64(activity.Time.Unix()), Member: id.String()})
+		pipe.ZRemRangeByRank(ctx, s.key("activity"), 0, -activeSubdomainsMax-1)
+
+		return nil
+	})
+
+	return err
+}
+
+func (s *RedisStore) GetActivity(ctx context.Context, id uuid.UUID) ([]Activity, error) {
+	vals, err := s.rdb.LRange(ctx, s.key("%s-activity", id), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Activity, 0, len(vals))
+
+	for _, val := range vals {
+		var activity Activity
+
+		if err := json.Unmarshal([]byte(val), &activity); err != nil {
+			return nil, err
+		}
+
+		res = append(res, activity)
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) ListActiveSubdomains(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	vals, err := s.rdb.ZRevRange(ctx, s.key("activity"), 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]uuid.UUID, 0, len(vals))
+
+	for _, val := range vals {
+		id, err := uuid.Parse(val)
+		if err != nil {
+			continue
+		}
+
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+func (s *RedisStore) AddAbuseReport(ctx context.Context, report AbuseReport) error {
+	val, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	if err := s.rdb.HSet(ctx, s.key("abuse-reports"), report.ID.String(), string(val)).Err(); err != nil {
+		return err
+	}
+
+	index := s.key("abuse-reports-index")
+
+	err = s.rdb.ZAdd(ctx, index, redis.Z{
+		Score:  float64(report.Time.Unix()),
+		Member: report.ID.String(),
+	}).Err()
+	if err != nil {
+		return err
+	}
+
+	expired, err := s.rdb.ZRangeByScore(ctx, index, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(time.Now().Add(-s.retention).Unix(), 10),
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	count, err := s.rdb.ZCard(ctx, index).Result()
+	if err != nil {
+		return err
+	}
+
+	if over := count - int64(len(expired)) - int64(s.maxReports); over > 0 {
+		oldest, err := s.rdb.ZRange(ctx, index, int64(len(expired)), int64(len(expired))+over-1).Result()
+		if err != nil {
+			return err
+		}
+
+		expired = append(expired, oldest...)
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	if err := s.rdb.HDel(ctx, s.key("abuse-reports"), expired...).Err(); err != nil {
+		return err
+	}
+
+	members := make([]any, len(expired))
+
+	for i, id := range expired {
+		members[i] = id
+	}
+
+	return s.rdb.ZRem(ctx, index, members...).Err()
+}
+
+func (s *RedisStore) ListAbuseReports(ctx context.Context) ([]AbuseReport, error) {
+	vals, err := s.rdb.HVals(ctx, s.key("abuse-reports")).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]AbuseReport, 0, len(vals))
+	cutoff := time.Now().Add(-s.retention)
+
+	var expired []string
+
+	for _, val := range vals {
+		var report AbuseReport
+
+		if err := json.Unmarshal([]byte(val), &report); err != nil {
+			return nil, err
+		}
+
+		// Reports stored before the index existed are only removed here
+		if report.Time.Before(cutoff) {
+			expired = append(expired, report.ID.String())
+
+			continue
+		}
+
+		res = append(res, report)
+	}
+
+	if len(expired) > 0 {
+		if err := s.rdb.HDel(ctx, s.key("abuse-reports"), expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Time.Before(res[j].Time)
+	})
+
+	return res, nil
+}
+
+func (s *RedisStore) DeleteAbuseReport(ctx context.Context, id uuid.UUID) error {
+	if err := s.rdb.HDel(ctx, s.key("abuse-reports"), id.String()).Err(); err != nil {
+		return err
+	}
+
+	return s.rdb.ZRem(ctx, s.key("abuse-reports-index"), id.String()).Err()
+}
+
+// incrementRateScript counts an event, starting the window on the first.
+var incrementRateScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+func (s *RedisStore) IncrementRate(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	res, err := incrementRateScript.Run(ctx, s.rdb, []string{s.key("rate-%s", key)}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return res[0], time.Duration(res[1]) * time.Millisecond, nil
+}
+
+var zoneSerialScript = redis.NewScript(`
+local current = redis.call("HMGET", KEYS[1], "hash", "serial")
+local serial = tonumber(current[2])
+if serial and current[1] == ARGV[1] then
+	return serial
+end
+if serial then
+	serial = (serial + 1) % 4294967296
+else
+	serial = tonumber(ARGV[2])
+end
+redis.call("HSET", KEYS[1], "hash", ARGV[1], "serial", serial)
+return serial
+`)
+
+func (s *RedisStore) ZoneSerial(ctx context.Context, hash string, initial uint32) (uint32, error) {
+	serial, err := zoneSerialScript.Run(ctx, s.rdb, []string{s.key("zone-serial")}, hash, initial).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(serial), nil
+}
+
+func (s *RedisStore) PublishClusterEvent(ctx context.Context, event ClusterEvent) error {
+	val, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Publish(ctx, s.key("cluster"), string(val)).Err()
+}
+
+func (s *RedisStore) SubscribeClusterEvents(ctx context.Context) <-chan ClusterEvent {
+	sub := s.rdb.Subscribe(ctx, s.key("cluster"))
+	events := make(chan ClusterEvent)
+
+	go func() {
+		defer close(events)
+		defer sub.Close()
+
+		messages := sub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event ClusterEvent
+
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case events <- event:
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+func (s *RedisStore) IncrementStat(_ context.Context, _ string, _ int64) {
+}
+
+type memChallenge struct {
+	expires time.Time
+	values  []string
+}
+
+type memRate struct {
+	count   int64
+	expires time.Time
+}
+
+type memLabel struct {
+	id      uuid.UUID
+	expires time.Time
+}
+
+type MemStore struct {
+	mu         sync.Mutex
+	challenges map[uuid.UUID]memChallenge
+	zone       map[string]memChallenge
+	labels     map[string]uuid.UUID
+	vanity     map[string]memLabel
+	vanityOf   map[uuid.UUID]string
+	delegated  map[uuid.UUID]*Delegation
+	policies   map[uuid.UUID][]string
+	services   map[uuid.UUID]*ServiceHints
+	caa        map[uuid.UUID][]CAARecord
+	blocks     map[uuid.UUID]SubdomainBlock
+	revoked    map[uuid.UUID]time.Time
+	tokenGens  map[uuid.UUID]int64
+	cidrs      []string
+	accounts   map[string]Account
+	owners     map[uuid.UUID]uuid.UUID
+	owned      map[uuid.UUID]map[uuid.UUID]AccountSubdomain
+	webhooks   map[uuid.UUID]*Webhook
+	deliveries map[uuid.UUID]*WebhookDelivery
+	history    map[uuid.UUID][]uuid.UUID
+	queue      map[uuid.UUID]time.Time
+	activity   map[uuid.UUID][]Activity
+	reports    map[uuid.UUID]AbuseReport
+	rates      map[string]memRate
+	zoneHash   string
+	zoneSerial uint32
+	maxReports int
+	retention  time.Duration
+	logger     *zap.SugaredLogger
+	stats      map[string]int64
+	statsFile  string
+	maxEntries int
+	sweep      time.Duration
+}
+
+func NewMemStore(logger *zap.SugaredLogger, cfg Config) (*MemStore, error) {
+	stats := map[string]int64{}
+
+	file, err := os.Open(cfg.statsFile())
+	if err == nil {
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &stats); err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Errorw("Failed to read stats file", "err", err)
+	}
+
+	return &MemStore{
+		challenges: map[uuid.UUID]memChallenge{},
+		zone:       map[string]memChallenge{},
+		labels:     map[string]uuid.UUID{},
+		vanity:     map[string]memLabel{},
+		vanityOf:   map[uuid.UUID]string{},
+		delegated:  map[uuid.UUID]*Delegation{},
+		policies:   map[uuid.UUID][]string{},
+		services:   map[uuid.UUID]*ServiceHints{},
+		caa:        map[uuid.UUID][]CAARecord{},
+		blocks:     map[uuid.UUID]SubdomainBlock{},
+		revoked:    map[uuid.UUID]time.Time{},
+		tokenGens:  map[uuid.UUID]int64{},
+		accounts:   map[string]Account{},
+		owners:     map[uuid.UUID]uuid.UUID{},
+		owned:      map[uuid.UUID]map[uuid.UUID]AccountSubdomain{},
+		webhooks:   map[uuid.UUID]*Webhook{},
+		deliveries: map[uuid.UUID]*WebhookDelivery{},
+		history:    map[uuid.UUID][]uuid.UUID{},
+		queue:      map[uuid.UUID]time.Time{},
+		activity:   map[uuid.UUID][]Activity{},
+		reports:    map[uuid.UUID]AbuseReport{},
+		rates:      map[string]memRate{},
+		maxReports: cfg.abuseReportsMax(),
+		retention:  cfg.abuseRetention(),
+		logger:     logger,
+		stats:      stats,
+		statsFile:  cfg.statsFile(),
+		maxEntries: cfg.challengeMaxEntries(),
+		sweep:      cfg.storeSweepInterval(),
+	}, nil
+}
+
+func (s *MemStore) SetACMEChallengeTokens(_ context.Context, id uuid.UUID, tokens []string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.challenges[id] = memChallenge{
+		expires: time.Now().Add(ttl),
+		values:  append([]string(nil), tokens...),
+	}
+
+	return nil
+}
+
+func (s *MemStore) GetACMEChallengeTokens(_ context.Context, id uuid.UUID) ([]string, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.challenges[id]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, time.Time{}, nil
+	}
+
+	// Copied, as callers read the values after the lock is released
+	return append([]string(nil), entry.values...), entry.expires, nil
+}
+
+func (s *MemStore) SetZoneChallengeTokens(_ context.Context, name string, tokens []string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(tokens) == 0 {
+		delete(s.zone, name)
+
+		return nil
+	}
+
+	s.zone[name] = memChallenge{
+		expires: time.Now().Add(ttl),
+		values:  append([]string(nil), tokens...),
+	}
+
+	return nil
+}
+
+func (s *MemStore) GetZoneChallengeTokens(_ context.Context, name string) ([]string, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.zone[name]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, time.Time{}, nil
+	}
+
+	return append([]string(nil), entry.values...), entry.expires, nil
+}
+
+func (s *MemStore) ClaimSubdomainLabel(_ context.Context, label string, id uuid.UUID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.labels[label]; ok {
+		return false, nil
+	}
+
+	if entry, ok := s.vanity[label]; ok && time.Now().Before(entry.expires) {
+		return false, nil
+	}
+
+	s.labels[label] = id
+
+	return true, nil
+}
+
+func (s *MemStore) GetSubdomainLabel(_ context.Context, label string) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.labels[label]; ok {
+		return id, nil
+	}
+
+	if entry, ok := s.vanity[label]; ok && time.Now().Before(entry.expires) {
+		return entry.id, nil
+	}
+
+	return uuid.Nil, nil
+}
+
+func (s *MemStore) ClaimVanityLabel(
+	_ context.Context,
+	label string,
+	id uuid.UUID,
+	ttl time.Duration,
+) (bool, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	if _, ok := s.labels[label]; ok {
+		return false, "", nil
+	}
+
+	if entry, ok := s.vanity[label]; ok && entry.id != id && now.Before(entry.expires) {
+		return false, "", nil
+	}
+
+	s.vanity[label] = memLabel{id: id, expires: now.Add(ttl)}
+
+	prev := s.vanityOf[id]
+	s.vanityOf[id] = label
+
+	if prev == "" || prev == label {
+		return true, "", nil
+	}
+
+	if entry, ok := s.vanity[prev]; ok && entry.id == id {
+		delete(s.vanity, prev)
+	}
+
+	return true, prev, nil
+}
+
+func (s *MemStore) RefreshVanityLabel(_ context.Context, id uuid.UUID, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	label, ok := s.vanityOf[id]
+	if !ok {
+		return nil
+	}
+
+	if entry, ok := s.vanity[label]; ok && entry.id == id {
+		s.vanity[label] = memLabel{id: id, expires: time.Now().Add(ttl)}
+	}
+
+	return nil
+}
+
+func (s *MemStore) SetDelegation(_ context.Context, id uuid.UUID, delegation *Delegation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if delegation == nil {
+		delete(s.delegated, id)
+
+		return nil
+	}
+
+	s.delegated[id] = delegation
+
+	return nil
+}
+
+func (s *MemStore) GetDelegation(_ context.Context, id uuid.UUID) (*Delegation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.delegated[id], nil
+}
+
+func (s *MemStore) SetAddressPolicy(_ context.Context, id uuid.UUID, classes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if classes == nil {
+		delete(s.policies, id)
+	} else {
+		s.policies[id] = classes
+	}
+
+	return nil
+}
+
+func (s *MemStore) GetAddressPolicy(_ context.Context, id uuid.UUID) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.policies[id], nil
+}
+
+func (s *MemStore) SetServiceHints(_ context.Context, id uuid.UUID, hints *ServiceHints) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hints == nil {
+		delete(s.services, id)
+
+		return nil
+	}
+
+	s.services[id] = hints
+
+	return nil
+}
+
+func (s *MemStore) GetServiceHints(_ context.Context, id uuid.UUID) (*ServiceHints, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.services[id], nil
+}
+
+func (s *MemStore) SetCAARecords(_ context.Context, id uuid.UUID, records []CAARecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(records) == 0 {
+		delete(s.caa, id)
+
+		return nil
+	}
+
+	s.caa[id] = records
+
+	return nil
+}
+
+func (s *MemStore) GetCAARecords(_ context.Context, id uuid.UUID) ([]CAARecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.caa[id], nil
+}
+
+func (s *MemStore) BlockSubdomain(_ context.Context, block SubdomainBlock) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blocks[block.ID] = block
+
+	return nil
+}
+
+func (s *MemStore) UnblockSubdomain(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blocks, id)
+
+	return nil
+}
+
+func (s *MemStore) GetSubdomainBlock(_ context.Context, id uuid.UUID) (*SubdomainBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	block, ok := s.blocks[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &block, nil
+}
+
+func (s *MemStore) RevokeSubdomain(_ context.Context, id uuid.UUID, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	GetACMEChallengeTokens(ctx context.Context, id uuid.UUID) ([]string, error)
+	s.revoked[id] = at
 
-	IncrementStat(ctx context.Context, key string, value int64)
+	return nil
 }
 
-type RedisStore struct {
-	rdb *redis.Client
+func (s *MemStore) GetSubdomainRevocation(_ context.Context, id uuid.UUID) (*time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at, ok := s.revoked[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &at, nil
 }
 
-func NewRedisStore(cfg Config) *RedisStore {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     cfg.RedisAddr,
-		Username: cfg.RedisUser,
-		Password: cfg.RedisPass,
-		DB:       cfg.RedisDB,
-	})
+func (s *MemStore) RotateSubdomainToken(_ context.Context, id uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	return &RedisStore{
-		rdb: rdb,
+	s.tokenGens[id]++
+
+	return s.tokenGens[id], nil
+}
+
+func (s *MemStore) GetSubdomainTokenGeneration(_ context.Context, id uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokenGens[id], nil
+}
+
+func (s *MemStore) ListSubdomainBlocks(_ context.Context) ([]SubdomainBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]SubdomainBlock, 0, len(s.blocks))
+
+	for _, block := range s.blocks {
+		res = append(res, block)
 	}
+
+	return res, nil
 }
 
-func (s *RedisStore) SetACMEChallengeTokens(ctx context.Context, id uuid.UUID, tokens []string) error {
-	val, err := json.Marshal(tokens)
-	if err != nil {
-		return err
+func (s *MemStore) SetForbiddenCIDRs(_ context.Context, cidrs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cidrs = cidrs
+
+	return nil
+}
+
+func (s *MemStore) GetForbiddenCIDRs(_ context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cidrs, nil
+}
+
+func (s *MemStore) CreateAccount(_ context.Context, account Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[account.KeyHash] = account
+
+	return nil
+}
+
+func (s *MemStore) GetAccount(_ context.Context, keyHash string) (*Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[keyHash]
+	if !ok {
+		return nil, nil
 	}
 
-	return s.rdb.Set(ctx, fmt.Sprintf("%s-acme-challenge", id), string(val), time.Hour).Err()
+	return &account, nil
 }
 
-func (s *RedisStore) GetACMEChallengeTokens(ctx context.Context, id uuid.UUID) ([]string, error) {
-	var res []string
+func (s *MemStore) AddAccountSubdomain(
+	_ context.Context,
+	accountID uuid.UUID,
+	sub AccountSubdomain,
+	limit int,
+) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	val, err := s.rdb.Get(ctx, fmt.Sprintf("%s-acme-challenge", id)).Result()
-	if errors.Is(err, redis.Nil) {
-		return res, nil
-	} else if err != nil {
-		return nil, err
+	if len(s.owned[accountID]) >= limit {
+		return false, nil
 	}
 
-	if err := json.Unmarshal([]byte(val), &res); err != nil {
-		return nil, err
+	if s.owned[accountID] == nil {
+		s.owned[accountID] = map[uuid.UUID]AccountSubdomain{}
+	}
+
+	s.owned[accountID][sub.ID] = sub
+	s.owners[sub.ID] = accountID
+
+	return true, nil
+}
+
+func (s *MemStore) SetAccountSubdomain(_ context.Context, accountID uuid.UUID, sub AccountSubdomain) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.owned[accountID] == nil {
+		s.owned[accountID] = map[uuid.UUID]AccountSubdomain{}
+	}
+
+	s.owned[accountID][sub.ID] = sub
+	s.owners[sub.ID] = accountID
+
+	return nil
+}
+
+func (s *MemStore) RemoveAccountSubdomain(_ context.Context, accountID uuid.UUID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.owned[accountID], id)
+	delete(s.owners, id)
+
+	return nil
+}
+
+func (s *MemStore) GetSubdomainAccount(_ context.Context, id uuid.UUID) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.owners[id], nil
+}
+
+func (s *MemStore) ListAccountSubdomains(_ context.Context, accountID uuid.UUID) ([]AccountSubdomain, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]AccountSubdomain, 0, len(s.owned[accountID]))
+
+	for _, sub := range s.owned[accountID] {
+		res = append(res, sub)
 	}
 
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Created.Before(res[j].Created)
+	})
+
 	return res, nil
 }
 
-func (s *RedisStore) IncrementStat(_ context.Context, _ string, _ int64) {
+func (s *MemStore) SetWebhook(_ context.Context, id uuid.UUID, hook *Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hook == nil {
+		delete(s.webhooks, id)
+
+		return nil
+	}
+
+	s.webhooks[id] = hook
+
+	return nil
 }
 
-type memChallenge struct {
-	stamp  time.Time
-	values []string
+func (s *MemStore) GetWebhook(_ context.Context, id uuid.UUID) (*Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.webhooks[id], nil
 }
 
-type MemStore struct {
-	mu         sync.Mutex
-	challenges map[uuid.UUID]memChallenge
-	logger     *zap.SugaredLogger
-	stats      map[string]int64
+func (s *MemStore) AddWebhookDelivery(_ context.Context, delivery *WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := *delivery
+	s.deliveries[d.ID] = &d
+	s.queue[d.ID] = d.NextAttempt
+
+	history := append([]uuid.UUID{d.ID}, s.history[d.SubdomainID]...)
+	if len(history) > webhookHistoryLimit {
+		for _, id := range history[webhookHistoryLimit:] {
+			delete(s.deliveries, id)
+			delete(s.queue, id)
+		}
+
+		history = history[:webhookHistoryLimit]
+	}
+
+	s.history[d.SubdomainID] = history
+
+	return nil
 }
 
-func NewMemStore(logger *zap.SugaredLogger) (*MemStore, error) {
-	stats := map[string]int64{}
+func (s *MemStore) UpdateWebhookDelivery(_ context.Context, delivery *WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	file, err := os.Open("cache/stats.json")
-	if err == nil {
-		defer file.Close()
+	if _, ok := s.deliveries[delivery.ID]; !ok {
+		return nil
+	}
 
-		data, err := io.ReadAll(file)
-		if err != nil {
-			return nil, err
+	d := *delivery
+	s.deliveries[d.ID] = &d
+
+	if d.Status == WebhookPending {
+		s.queue[d.ID] = d.NextAttempt
+	} else {
+		delete(s.queue, d.ID)
+	}
+
+	return nil
+}
+
+func (s *MemStore) ClaimWebhookDeliveries(
+	_ context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]*WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []*WebhookDelivery
+
+	for id, due := range s.queue {
+		if len(res) >= limit {
+			break
 		}
 
-		if err := json.Unmarshal(data, &stats); err != nil {
-			return nil, err
+		if due.After(now) {
+			continue
 		}
-	} else {
-		logger.Errorw("Failed to read stats file", "err", err)
+
+		s.queue[id] = now.Add(lease)
+
+		d := *s.deliveries[id]
+		res = append(res, &d)
 	}
 
-	return &MemStore{
-		challenges: map[uuid.UUID]memChallenge{},
-		logger:     logger,
-		stats:      stats,
-	}, nil
+	return res, nil
 }
 
-func (s *MemStore) SetACMEChallengeTokens(_ context.Context, id uuid.UUID, tokens []string) error {
+func (s *MemStore) ListWebhookDeliveries(_ context.Context, id uuid.UUID) ([]*WebhookDelivery, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.challenges[id] = memChallenge{
-		stamp:  time.Now(),
-		values: tokens,
+	res := make([]*WebhookDelivery, 0, len(s.history[id]))
+
+	for _, deliveryID := range s.history[id] {
+		d := *s.deliveries[deliveryID]
+		res = append(res, &d)
 	}
 
+	return res, nil
+}
+
+func (s *MemStore) RecordActivity(_ context.Context, id uuid.UUID, activity Activity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := append([]Activity{activity}, s.activity[id]...)
+	if len(entries) > activityLimit {
+		entries = entries[:activityLimit]
+	}
+
+	s.activity[id] = entries
+
 	return nil
 }
 
-func (s *MemStore) GetACMEChallengeTokens(_ context.Context, id uuid.UUID) ([]string, error) {
+func (s *MemStore) GetActivity(_ context.Context, id uuid.UUID) ([]Activity, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	entry, ok := s.challenges[id]
-	if !ok {
-		return nil, nil
+	return s.activity[id], nil
+}
+
+func (s *MemStore) ListActiveSubdomains(_ context.Context, limit int) ([]uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]uuid.UUID, 0, len(s.activity))
+
+	for id := range s.activity {
+		res = append(res, id)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return s.activity[res[i]][0].Time.After(s.activity[res[j]][0].Time)
+	})
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (s *MemStore) AddAbuseReport(_ context.Context, report AbuseReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports[report.ID] = report
+
+	cutoff := time.Now().Add(-s.retention)
+
+	for id, r := range s.reports {
+		if r.Time.Before(cutoff) {
+			delete(s.reports, id)
+		}
+	}
+
+	if len(s.reports) <= s.maxReports {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(s.reports))
+
+	for id := range s.reports {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return s.reports[ids[i]].Time.Before(s.reports[ids[j]].Time)
+	})
+
+	for _, id := range ids[:len(ids)-s.maxReports] {
+		delete(s.reports, id)
+	}
+
+	return nil
+}
+
+func (s *MemStore) ListAbuseReports(_ context.Context) ([]AbuseReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]AbuseReport, 0, len(s.reports))
+
+	for _, report := range s.reports {
+		res = append(res, report)
 	}
 
-	return entry.values, nil
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Time.Before(res[j].Time)
+	})
+
+	return res, nil
+}
+
+func (s *MemStore) DeleteAbuseReport(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.reports, id)
+
+	return nil
 }
 
 func (s *MemStore) IncrementStat(_ context.Context, key string, value int64) {
@@ -143,8 +1968,42 @@ func (s *MemStore) IncrementStat(_ context.Context, key string, value int64) {
 	}()
 }
 
+func (s *MemStore) IncrementRate(_ context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	rate, ok := s.rates[key]
+	if !ok || now.After(rate.expires) {
+		rate = memRate{expires: now.Add(window)}
+	}
+
+	rate.count++
+	s.rates[key] = rate
+
+	return rate.count, rate.expires.Sub(now), nil
+}
+
+func (s *MemStore) ZoneSerial(_ context.Context, hash string, initial uint32) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.zoneHash == hash:
+	case s.zoneHash == "":
+		s.zoneSerial = initial
+	default:
+		s.zoneSerial++
+	}
+
+	s.zoneHash = hash
+
+	return s.zoneSerial, nil
+}
+
 func (s *MemStore) AutoCleanup() {
-	for range time.Tick(30 * time.Second) {
+	for range time.Tick(s.sweep) {
 		s.Clean()
 	}
 }
@@ -154,7 +2013,6 @@ func (s *MemStore) Clean() {
 	defer s.mu.Unlock()
 
 	now := time.Now()
-	oldest := now.Add(-30 * time.Minute)
 
 	type kv struct {
 		key   uuid.UUID
@@ -167,7 +2025,7 @@ func (s *MemStore) Clean() {
 	removed := 0
 
 	for k, v := range s.challenges {
-		if v.stamp.Unix() < oldest.Unix() {
+		if now.After(v.expires) {
 			delete(s.challenges, k)
 
 			removed++
@@ -175,19 +2033,57 @@ func (s *MemStore) Clean() {
 			continue
 		}
 
-		ss = append(ss, kv{k, v.stamp})
+		ss = append(ss, kv{k, v.expires})
+	}
+
+	for k, v := range s.zone {
+		if now.After(v.expires) {
+			delete(s.zone, k)
+		}
+	}
+
+	for k, v := range s.rates {
+		if now.After(v.expires) {
+			delete(s.rates, k)
+		}
+	}
+
+	for k, v := range s.vanity {
+		if now.After(v.expires) {
+			delete(s.vanity, k)
+
+			if s.vanityOf[v.id] == k {
+				delete(s.vanityOf, v.id)
+			}
+		}
 	}
 
 	sort.Slice(ss, func(i, j int) bool {
 		return ss[i].value.Unix() > ss[j].value.Unix()
 	})
 
-	for i := len(s.challenges) - 1; i >= 1000000; i-- {
+	for i := len(s.challenges) - 1; i >= s.maxEntries; i-- {
 		delete(s.challenges, ss[i].key)
 
 		removed++
 	}
 
+	if len(s.activity) > activeSubdomainsMax {
+		ids := make([]uuid.UUID, 0, len(s.activity))
+
+		for id := range s.activity {
+			ids = append(ids, id)
+		}
+
+		sort.Slice(ids, func(i, j int) bool {
+			return s.activity[ids[i]][0].Time.After(s.activity[ids[j]][0].Time)
+		})
+
+		for _, id := range ids[activeSubdomainsMax:] {
+			delete(s.activity, id)
+		}
+	}
+
 	s.logger.Debugw("Store cleaned", "acme_active", len(s.challenges), "acme_removed", removed, "stats", s.stats)
 
 	encoded, err := json.MarshalIndent(s.stats, "", "    ")
@@ -197,7 +2093,7 @@ func (s *MemStore) Clean() {
 		return
 	}
 
-	if err := os.WriteFile("cache/stats.json", encoded, 0o644); err != nil {
+	if err := os.WriteFile(s.statsFile, encoded, 0o644); err != nil {
 		s.logger.Warnw("Failed to write stats", "err", err)
 	}
 }
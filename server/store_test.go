@@ -0,0 +1,316 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The MemStore tests pin the behaviour the Redis scripts implement, so both
+// stores stay interchangeable.
+
+func TestMemStoreClaimVanityLabel(t *testing.T) {
+	ctx := context.Background()
+	other := uuid.MustParse("9b2c1f0e-6f4b-4f1a-8a43-2d7f5c1e0b3a")
+
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, store *MemStore)
+		label    string
+		id       uuid.UUID
+		claimed  bool
+		previous string
+	}{
+		{
+			name:    "free",
+			label:   "home",
+			id:      testID,
+			claimed: true,
+		},
+		{
+			name: "short label of the same owner",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if _, err := store.ClaimSubdomainLabel(ctx, "k2v7qmxa", testID); err != nil {
+					t.Fatal(err)
+				}
+			},
+			label: "k2v7qmxa",
+			id:    testID,
+		},
+		{
+			name: "held by another owner",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if _, _, err := store.ClaimVanityLabel(ctx, "home", other, time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			},
+			label: "home",
+			id:    testID,
+		},
+		{
+			name: "expired for another owner",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if _, _, err := store.ClaimVanityLabel(ctx, "home", other, -time.Second); err != nil {
+					t.Fatal(err)
+				}
+			},
+			label:   "home",
+			id:      testID,
+			claimed: true,
+		},
+		{
+			name: "renewed by the same owner",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if _, _, err := store.ClaimVanityLabel(ctx, "home", testID, time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			},
+			label:   "home",
+			id:      testID,
+			claimed: true,
+		},
+		{
+			name: "replaces the previous label",
+			setup: func(t *testing.T, store *MemStore) {
+				t.Helper()
+
+				if _, _, err := store.ClaimVanityLabel(ctx, "office", testID, time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			},
+			label:    "home",
+			id:       testID,
+			claimed:  true,
+			previous: "office",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			if tt.setup != nil {
+				tt.setup(t, store)
+			}
+
+			claimed, previous, err := store.ClaimVanityLabel(ctx, tt.label, tt.id, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if claimed != tt.claimed || previous != tt.previous {
+				t.Fatalf("expected (%v, %q), got (%v, %q)", tt.claimed, tt.previous, claimed, previous)
+			}
+
+			if previous == "" {
+				return
+			}
+
+			owner, err := store.GetSubdomainLabel(ctx, previous)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if owner != uuid.Nil {
+				t.Fatalf("expected %q to be released, still owned by %s", previous, owner)
+			}
+		})
+	}
+}
+
+func TestMemStoreReleaseKeepsShortLabel(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if _, err := store.ClaimSubdomainLabel(ctx, "k2v7qmxa", testID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := store.ClaimVanityLabel(ctx, "home", testID, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := store.ClaimVanityLabel(ctx, "office", testID, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	owner, err := store.GetSubdomainLabel(ctx, "k2v7qmxa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if owner != testID {
+		t.Fatalf("expected the short label to survive, got owner %s", owner)
+	}
+}
+
+func TestMemStoreIncrementRate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		window   time.Duration
+		calls    int
+		expected int64
+	}{
+		{name: "first event", window: time.Hour, calls: 1, expected: 1},
+		{name: "within window", window: time.Hour, calls: 3, expected: 3},
+		{name: "window passed", window: -time.Second, calls: 3, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			var (
+				count int64
+				reset time.Duration
+				err   error
+			)
+
+			for i := 0; i < tt.calls; i++ {
+				count, reset, err = store.IncrementRate(ctx, "test", tt.window)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if count != tt.expected {
+				t.Fatalf("expected count %d, got %d", tt.expected, count)
+			}
+
+			if reset > tt.window {
+				t.Fatalf("expected reset within %s, got %s", tt.window, reset)
+			}
+		})
+	}
+}
+
+func TestMemStoreZoneSerial(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		hashes   []string
+		expected uint32
+	}{
+		{name: "initial", hashes: []string{"a"}, expected: 100},
+		{name: "unchanged", hashes: []string{"a", "a"}, expected: 100},
+		{name: "changed", hashes: []string{"a", "b"}, expected: 101},
+		{name: "changed back", hashes: []string{"a", "b", "a"}, expected: 102},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			var serial uint32
+
+			for _, hash := range tt.hashes {
+				var err error
+
+				serial, err = store.ZoneSerial(ctx, hash, 100)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if serial != tt.expected {
+				t.Fatalf("expected serial %d, got %d", tt.expected, serial)
+			}
+		})
+	}
+}
+
+func TestMemStoreAccountSubdomains(t *testing.T) {
+	ctx := context.Background()
+	account := uuid.MustParse("3f1d2c4b-8e5a-4b7c-9d6e-0a1b2c3d4e5f")
+
+	store := newTestStore(t)
+
+	for i := 0; i < 2; i++ {
+		added, err := store.AddAccountSubdomain(ctx, account, AccountSubdomain{ID: uuid.New()}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !added {
+			t.Fatalf("expected slot %d to be granted", i)
+		}
+	}
+
+	added, err := store.AddAccountSubdomain(ctx, account, AccountSubdomain{ID: testID}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if added {
+		t.Fatal("expected the limit to be enforced")
+	}
+
+	subs, err := store.ListAccountSubdomains(ctx, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.RemoveAccountSubdomain(ctx, account, subs[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	owner, err := store.GetSubdomainAccount(ctx, subs[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if owner != uuid.Nil {
+		t.Fatalf("expected the released subdomain to have no owner, got %s", owner)
+	}
+
+	added, err = store.AddAccountSubdomain(ctx, account, AccountSubdomain{ID: testID}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !added {
+		t.Fatal("expected the released slot to be reusable")
+	}
+}
+
+func TestMemStoreRotateSubdomainToken(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	gen, err := store.GetSubdomainTokenGeneration(ctx, testID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gen != 0 {
+		t.Fatalf("expected generation 0, got %d", gen)
+	}
+
+	for want := int64(1); want <= 2; want++ {
+		gen, err = store.RotateSubdomainToken(ctx, testID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if gen != want {
+			t.Fatalf("expected generation %d, got %d", want, gen)
+		}
+	}
+
+	if got, _ := store.GetSubdomainTokenGeneration(ctx, testID); got != gen {
+		t.Fatalf("expected stored generation %d, got %d", gen, got)
+	}
+}
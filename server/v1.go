@@ -4,98 +4,964 @@ import (
 	"context"
 	"crypto/sha512"
 	"crypto/subtle"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"net"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	v1 "github.com/csnewman/dyndirect/server/internal/v1"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
-var errRequestMissingInCtx = errors.New("request missing in ctx")
+var (
+	errRequestMissingInCtx = errors.New("request missing in ctx")
+	errInvalidRemoteAddr   = errors.New("invalid remote address")
+)
 
 type v1API struct {
-	tokenHash  []byte
-	store      Store
-	rootDomain string
+	logger          *zap.SugaredLogger
+	tokenHash       []byte
+	store           Store
+	rootDomain      string
+	idScheme        string
+	challengeTTL    time.Duration
+	zone            *atomic.Pointer[staticZone]
+	addressClasses  map[string]struct{}
+	behindProxy     bool
+	discovery       map[string]v1.DiscoveryResponse
+	webhooks        *webhookSender
+	webhookPrivate  bool
+	maxSubdomains   int
+	labelTTL        time.Duration
+	abuseReportRate int
+	accountRate     int
 }
 
 func (v *v1API) GetOverview(
 	ctx context.Context,
 	_ v1.GetOverviewRequestObject,
 ) (v1.GetOverviewResponseObject, error) {
+	userIP, err := requestIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1.GetOverview200JSONResponse{
+		Version:  Version,
+		ClientIp: userIP.String(),
+	}, nil
+}
+
+func (v *v1API) GetDiscovery(
+	ctx context.Context,
+	_ v1.GetDiscoveryRequestObject,
+) (v1.GetDiscoveryResponseObject, error) {
 	r, ok := requestFromCtx(ctx)
 	if !ok {
 		return nil, errRequestMissingInCtx
 	}
 
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	return v1.GetDiscovery200JSONResponse(v.discovery[requestScheme(r, v.behindProxy)]), nil
+}
+
+func (v *v1API) GenerateSubdomain(
+	ctx context.Context,
+	r v1.GenerateSubdomainRequestObject,
+) (v1.GenerateSubdomainResponseObject, error) {
+	scheme := v.idScheme
+	if r.Params.Scheme != nil {
+		scheme = string(*r.Params.Scheme)
+	}
+
+	if scheme != SchemeUUID && scheme != SchemeShort {
+		return v1.GenerateSubdomain400JSONResponse{
+			Error:   "unsupported-scheme",
+			Message: "The requested scheme is not supported by this server.",
+		}, nil
+	}
+
+	id, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
-	userIP := net.ParseIP(ip)
-	if userIP == nil {
+	domain, err := v.allocateSubdomain(ctx, id, scheme)
+	if err != nil {
 		return nil, err
 	}
 
-	return v1.GetOverview200JSONResponse{
-		Version:  Version,
-		ClientIp: userIP.String(),
+	return v1.GenerateSubdomain200JSONResponse{
+		Id:     id,
+		Token:  v.generateToken(id, 0),
+		Domain: domain,
 	}, nil
 }
 
-func (v *v1API) GenerateSubdomain(
+func (v *v1API) allocateSubdomain(ctx context.Context, id uuid.UUID, scheme string) (string, error) {
+	label := id.String()
+
+	if scheme == SchemeShort {
+		var err error
+
+		label, err = allocateShortLabel(ctx, v.store, id)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	domain := fmt.Sprintf("%s.%s", label, v.rootDomain)
+
+	v.store.IncrementStat(ctx, "api_subdomain_new", 1)
+
+	if err := v.recordActivity(ctx, id, "subdomain_new", domain); err != nil {
+		return "", err
+	}
+
+	return domain, nil
+}
+
+func (v *v1API) CreateAccount(
 	ctx context.Context,
-	_ v1.GenerateSubdomainRequestObject,
-) (v1.GenerateSubdomainResponseObject, error) {
+	r v1.CreateAccountRequestObject,
+) (v1.CreateAccountResponseObject, error) {
+	userIP, err := requestIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	count, reset, err := v.store.IncrementRate(ctx, "account-"+userIP.String(), accountCreateWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > int64(v.accountRate) {
+		v.store.IncrementStat(ctx, "api_account_limited", 1)
+
+		return v1.CreateAccount429JSONResponse{
+			Body: v1.ErrorResponse{
+				Error:   "too-many-requests",
+				Message: "Too many accounts have been created from your IP address.",
+			},
+			Headers: v1.CreateAccount429ResponseHeaders{
+				RetryAfter: retryAfterSeconds(reset),
+			},
+		}, nil
+	}
+
 	id, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
-	token := v.generateToken(id)
+	key, err := generateAccountKey()
+	if err != nil {
+		return nil, err
+	}
+
+	account := Account{
+		ID:      id,
+		KeyHash: hashAccountKey(key),
+		Created: time.Now(),
+	}
 
-	domain := fmt.Sprintf("%s.%s", id, v.rootDomain)
+	if r.Body.Name != nil {
+		account.Name = *r.Body.Name
+	}
 
-	v.store.IncrementStat(ctx, "api_subdomain_new", 1)
+	if err := v.store.CreateAccount(ctx, account); err != nil {
+		return nil, err
+	}
 
-	return v1.GenerateSubdomain200JSONResponse{
+	v.store.IncrementStat(ctx, "api_account_new", 1)
+
+	return v1.CreateAccount200JSONResponse{
+		Id:  id,
+		Key: key,
+	}, nil
+}
+
+func (v *v1API) AccountListSubdomains(
+	ctx context.Context,
+	_ v1.AccountListSubdomainsRequestObject,
+) (v1.AccountListSubdomainsResponseObject, error) {
+	account, denied, err := v.authorizeAccount(ctx)
+	if err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.AccountListSubdomains401JSONResponse(*denied), nil
+	}
+
+	subs, err := v.store.ListAccountSubdomains(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := v1.AccountListSubdomains200JSONResponse{
+		Subdomains: make([]v1.AccountSubdomain, 0, len(subs)),
+	}
+
+	for _, sub := range subs {
+		entry := v1.AccountSubdomain{
+			Id:      sub.ID,
+			Domain:  sub.Domain,
+			Created: sub.Created,
+			Revoked: sub.Revoked,
+		}
+
+		if sub.Description != "" {
+			description := sub.Description
+			entry.Description = &description
+		}
+
+		res.Subdomains = append(res.Subdomains, entry)
+	}
+
+	v.store.IncrementStat(ctx, "api_account_list", 1)
+
+	return res, nil
+}
+
+func (v *v1API) AccountAllocateSubdomain(
+	ctx context.Context,
+	r v1.AccountAllocateSubdomainRequestObject,
+) (v1.AccountAllocateSubdomainResponseObject, error) {
+	account, denied, err := v.authorizeAccount(ctx)
+	if err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.AccountAllocateSubdomain401JSONResponse(*denied), nil
+	}
+
+	scheme := v.idScheme
+	if r.Params.Scheme != nil {
+		scheme = string(*r.Params.Scheme)
+	}
+
+	if scheme != SchemeUUID && scheme != SchemeShort {
+		return v1.AccountAllocateSubdomain400JSONResponse{
+			Error:   "unsupported-scheme",
+			Message: "The requested scheme is not supported by this server.",
+		}, nil
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	sub := AccountSubdomain{
+		ID:      id,
+		Created: time.Now(),
+	}
+
+	if r.Body.Description != nil {
+		sub.Description = *r.Body.Description
+	}
+
+	// The slot is reserved before a label is allocated, so concurrent requests
+	// cannot exceed the limit or leak labels when the account is full
+	added, err := v.store.AddAccountSubdomain(ctx, account.ID, sub, v.maxSubdomains)
+	if err != nil {
+		return nil, err
+	}
+
+	if !added {
+		return v1.AccountAllocateSubdomain400JSONResponse{
+			Error:   "account-full",
+			Message: "The account has reached its subdomain limit.",
+		}, nil
+	}
+
+	domain, err := v.allocateSubdomain(ctx, id, scheme)
+	if err != nil {
+		if rerr := v.store.RemoveAccountSubdomain(ctx, account.ID, id); rerr != nil {
+			v.logger.Errorw("Failed to release account subdomain", "account", account.ID, "id", id, "err", rerr)
+		}
+
+		return nil, err
+	}
+
+	sub.Domain = domain
+
+	if err := v.store.SetAccountSubdomain(ctx, account.ID, sub); err != nil {
+		return nil, err
+	}
+
+	return v1.AccountAllocateSubdomain200JSONResponse{
 		Id:     id,
-		Token:  token,
+		Token:  v.generateToken(id, 0),
 		Domain: domain,
 	}, nil
 }
 
+func (v *v1API) AccountRevokeSubdomains(
+	ctx context.Context,
+	r v1.AccountRevokeSubdomainsRequestObject,
+) (v1.AccountRevokeSubdomainsResponseObject, error) {
+	account, denied, err := v.authorizeAccount(ctx)
+	if err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.AccountRevokeSubdomains401JSONResponse(*denied), nil
+	}
+
+	subs, err := v.store.ListAccountSubdomains(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make(map[uuid.UUID]AccountSubdomain, len(subs))
+
+	for _, sub := range subs {
+		owned[sub.ID] = sub
+	}
+
+	res := v1.AccountRevokeSubdomains200JSONResponse{
+		Revoked: []uuid.UUID{},
+	}
+
+	for _, id := range r.Body.Ids {
+		sub, ok := owned[id]
+		if !ok || sub.Revoked != nil {
+			continue
+		}
+
+		now := time.Now()
+
+		if err := v.store.RevokeSubdomain(ctx, id, now); err != nil {
+			return nil, err
+		}
+
+		sub.Revoked = &now
+
+		if err := v.store.SetAccountSubdomain(ctx, account.ID, sub); err != nil {
+			return nil, err
+		}
+
+		if err := v.recordActivity(ctx, id, EventSubdomainRevoked, ""); err != nil {
+			return nil, err
+		}
+
+		res.Revoked = append(res.Revoked, id)
+	}
+
+	v.store.IncrementStat(ctx, "api_account_revoke", int64(len(res.Revoked)))
+
+	return res, nil
+}
+
 func (v *v1API) SubdomainAcmeChallenge(
 	ctx context.Context,
 	r v1.SubdomainAcmeChallengeRequestObject,
 ) (v1.SubdomainAcmeChallengeResponseObject, error) {
-	expectedToken := v.generateToken(r.SubdomainId)
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainAcmeChallenge403JSONResponse(*denied), nil
+	}
 
-	if subtle.ConstantTimeCompare([]byte(expectedToken), []byte(r.Body.Token)) != 1 {
-		v.store.IncrementStat(ctx, "api_token_invalid", 1)
+	ttl := v.challengeTTL
 
-		return v1.SubdomainAcmeChallenge403JSONResponse{
-			Error:   "invalid-token",
-			Message: "The provided token is not valid for the subdomain.",
-		}, nil
+	if r.Body.Ttl != nil {
+		if requested := time.Duration(*r.Body.Ttl) * time.Second; requested < ttl {
+			ttl = requested
+		}
 	}
 
-	if err := v.store.SetACMEChallengeTokens(ctx, r.SubdomainId, r.Body.Values); err != nil {
+	expires := time.Now().Add(ttl)
+
+	if err := v.store.SetACMEChallengeTokens(ctx, r.SubdomainId, r.Body.Values, ttl); err != nil {
 		return nil, err
 	}
 
 	v.store.IncrementStat(ctx, "api_acme_set", 1)
 
-	return v1.SubdomainAcmeChallenge200Response{}, nil
+	if err := v.recordActivity(ctx, r.SubdomainId, "acme_set", ""); err != nil {
+		return nil, err
+	}
+
+	return v1.SubdomainAcmeChallenge200JSONResponse{
+		Ttl:     int(ttl / time.Second),
+		Expires: expires,
+	}, nil
 }
 
-func (v *v1API) generateToken(id uuid.UUID) string {
-	buf := v.tokenHash
+func (v *v1API) SubdomainClaimLabel(
+	ctx context.Context,
+	r v1.SubdomainClaimLabelRequestObject,
+) (v1.SubdomainClaimLabelResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainClaimLabel403JSONResponse(*denied), nil
+	}
+
+	label := strings.ToLower(r.Body.Label)
+
+	if !validVanityLabel(label, v.zone.Load().reserved) {
+		return v1.SubdomainClaimLabel400JSONResponse{
+			Error:   "invalid-label",
+			Message: "The label is not valid or is reserved.",
+		}, nil
+	}
+
+	// A subdomain holds a single vanity label, so claiming another releases it
+	ok, _, err := v.store.ClaimVanityLabel(ctx, label, r.SubdomainId, v.labelTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return v1.SubdomainClaimLabel409JSONResponse{
+			Error:   "label-taken",
+			Message: "The label has already been claimed.",
+		}, nil
+	}
+
+	v.store.IncrementStat(ctx, "api_label_claimed", 1)
+
+	domain := fmt.Sprintf("%s.%s", label, v.rootDomain)
+
+	if err := v.recordActivity(ctx, r.SubdomainId, "label_claimed", domain); err != nil {
+		return nil, err
+	}
+
+	return v1.SubdomainClaimLabel200JSONResponse{
+		Domain: domain,
+	}, nil
+}
+
+func (v *v1API) SubdomainDelegation(
+	ctx context.Context,
+	r v1.SubdomainDelegationRequestObject,
+) (v1.SubdomainDelegationResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainDelegation403JSONResponse(*denied), nil
+	}
+
+	delegation, err := parseDelegation(r.Body, v.rootDomain+".")
+	if err != nil {
+		return v1.SubdomainDelegation400JSONResponse{
+			Error:   "invalid-delegation",
+			Message: fmt.Sprintf("The delegation is not valid: %s.", err),
+		}, nil
+	}
+
+	// Glue may only be published for names within the delegated subdomain
+	if delegation != nil {
+		for _, glue := range delegation.Glue {
+			owner, err := resolveSubdomain(ctx, v.store, glueLabel(glue.Name, v.rootDomain+"."))
+			if err != nil {
+				return nil, err
+			}
+
+			if owner != r.SubdomainId {
+				return v1.SubdomainDelegation400JSONResponse{
+					Error:   "invalid-delegation",
+					Message: fmt.Sprintf("The delegation is not valid: glue name '%s' is not within the subdomain.", glue.Name),
+				}, nil
+			}
+		}
+	}
+
+	if err := v.store.SetDelegation(ctx, r.SubdomainId, delegation); err != nil {
+		return nil, err
+	}
+
+	v.store.IncrementStat(ctx, "api_delegation_set", 1)
+
+	if err := v.recordActivity(ctx, r.SubdomainId, "delegation_set", strings.Join(r.Body.Nameservers, ",")); err != nil {
+		return nil, err
+	}
+
+	return v1.SubdomainDelegation200Response{}, nil
+}
+
+func (v *v1API) SubdomainAddressPolicy(
+	ctx context.Context,
+	r v1.SubdomainAddressPolicyRequestObject,
+) (v1.SubdomainAddressPolicyResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainAddressPolicy403JSONResponse(*denied), nil
+	}
+
+	// Omitting the classes restores the server default, while an empty list
+	// blocks every class
+	var classes []string
+
+	if r.Body.Classes != nil {
+		classes = make([]string, 0, len(*r.Body.Classes))
+
+		for _, class := range *r.Body.Classes {
+			classes = append(classes, string(class))
+		}
+	}
+
+	if err := v.store.SetAddressPolicy(ctx, r.SubdomainId, classes); err != nil {
+		return nil, err
+	}
+
+	v.store.IncrementStat(ctx, "api_address_policy_set", 1)
+
+	if err := v.recordActivity(ctx, r.SubdomainId, "address_policy_set", strings.Join(classes, ",")); err != nil {
+		return nil, err
+	}
+
+	effective := intersectClasses(v.addressClasses, classes)
+	res := v1.SubdomainAddressPolicy200JSONResponse{
+		Classes: make([]v1.AddressClass, 0, len(effective)),
+	}
+
+	for _, class := range effective {
+		res.Classes = append(res.Classes, v1.AddressClass(class))
+	}
+
+	return res, nil
+}
+
+func (v *v1API) SubdomainService(
+	ctx context.Context,
+	r v1.SubdomainServiceRequestObject,
+) (v1.SubdomainServiceResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainService403JSONResponse(*denied), nil
+	}
+
+	var hints *ServiceHints
+
+	if r.Body.Port != nil || (r.Body.Alpn != nil && len(*r.Body.Alpn) > 0) {
+		hints = &ServiceHints{}
+
+		if r.Body.Port != nil {
+			hints.Port = uint16(*r.Body.Port)
+		}
+
+		if r.Body.Alpn != nil {
+			for _, proto := range *r.Body.Alpn {
+				hints.ALPN = append(hints.ALPN, string(proto))
+			}
+		}
+	}
+
+	if err := v.store.SetServiceHints(ctx, r.SubdomainId, hints); err != nil {
+		return nil, err
+	}
+
+	v.store.IncrementStat(ctx, "api_service_set", 1)
+
+	detail := ""
+	if hints != nil {
+		detail = fmt.Sprintf("%d %s", hints.Port, strings.Join(hints.ALPN, ","))
+	}
+
+	if err := v.recordActivity(ctx, r.SubdomainId, "service_set", detail); err != nil {
+		return nil, err
+	}
+
+	return v1.SubdomainService200Response{}, nil
+}
+
+func (v *v1API) SubdomainCaa(
+	ctx context.Context,
+	r v1.SubdomainCaaRequestObject,
+) (v1.SubdomainCaaResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainCaa403JSONResponse(*denied), nil
+	}
+
+	records, err := parseCAA(r.Body)
+	if err != nil {
+		return v1.SubdomainCaa400JSONResponse{
+			Error:   "invalid-caa",
+			Message: fmt.Sprintf("The CAA records are not valid: %s.", err),
+		}, nil
+	}
+
+	if err := v.store.SetCAARecords(ctx, r.SubdomainId, records); err != nil {
+		return nil, err
+	}
+
+	v.store.IncrementStat(ctx, "api_caa_set", 1)
+
+	tags := make([]string, 0, len(records))
+
+	for _, record := range records {
+		tags = append(tags, record.Tag)
+	}
+
+	if err := v.recordActivity(ctx, r.SubdomainId, "caa_set", strings.Join(tags, ",")); err != nil {
+		return nil, err
+	}
+
+	return v1.SubdomainCaa200Response{}, nil
+}
+
+func (v *v1API) SubdomainWebhook(
+	ctx context.Context,
+	r v1.SubdomainWebhookRequestObject,
+) (v1.SubdomainWebhookResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainWebhook403JSONResponse(*denied), nil
+	}
+
+	if r.Body.Url == nil || *r.Body.Url == "" {
+		if err := v.store.SetWebhook(ctx, r.SubdomainId, nil); err != nil {
+			return nil, err
+		}
+
+		v.store.IncrementStat(ctx, "api_webhook_set", 1)
+
+		if err := v.recordActivity(ctx, r.SubdomainId, EventWebhookSet, ""); err != nil {
+			return nil, err
+		}
+
+		return v1.SubdomainWebhook200JSONResponse{}, nil
+	}
+
+	u, err := parseWebhookURL(*r.Body.Url, v.webhookPrivate)
+	if err != nil {
+		return v1.SubdomainWebhook400JSONResponse{
+			Error:   "invalid-webhook",
+			Message: fmt.Sprintf("The webhook is not valid: %s.", err),
+		}, nil
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &Webhook{
+		URL:    u.String(),
+		Secret: secret,
+	}
+
+	if r.Body.Events != nil {
+		for _, event := range *r.Body.Events {
+			if !validWebhookEvent(string(event)) {
+				return v1.SubdomainWebhook400JSONResponse{
+					Error:   "invalid-webhook",
+					Message: fmt.Sprintf("The webhook is not valid: unknown event '%s'.", event),
+				}, nil
+			}
+
+			hook.Events = append(hook.Events, string(event))
+		}
+	}
+
+	if err := v.store.SetWebhook(ctx, r.SubdomainId, hook); err != nil {
+		return nil, err
+	}
+
+	v.store.IncrementStat(ctx, "api_webhook_set", 1)
+
+	if err := v.recordActivity(ctx, r.SubdomainId, EventWebhookSet, u.Host); err != nil {
+		return nil, err
+	}
+
+	return v1.SubdomainWebhook200JSONResponse{
+		Secret: &secret,
+	}, nil
+}
+
+func (v *v1API) SubdomainWebhookStatus(
+	ctx context.Context,
+	r v1.SubdomainWebhookStatusRequestObject,
+) (v1.SubdomainWebhookStatusResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainWebhookStatus403JSONResponse(*denied), nil
+	}
+
+	hook, err := v.store.GetWebhook(ctx, r.SubdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := v.store.ListWebhookDeliveries(ctx, r.SubdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := v1.SubdomainWebhookStatus200JSONResponse{
+		Events:     []v1.WebhookEvent{},
+		Deliveries: make([]v1.WebhookDelivery, 0, len(deliveries)),
+	}
+
+	if hook != nil {
+		res.Url = &hook.URL
+
+		events := hook.Events
+		if len(events) == 0 {
+			events = webhookEvents
+		}
+
+		for _, event := range events {
+			res.Events = append(res.Events, v1.WebhookEvent(event))
+		}
+	}
+
+	for _, d := range deliveries {
+		delivery := v1.WebhookDelivery{
+			Id:       d.ID,
+			Event:    v1.WebhookEvent(d.Event),
+			Created:  d.Created,
+			Status:   v1.WebhookDeliveryStatus(d.Status),
+			Attempts: d.Attempts,
+		}
+
+		if d.Status == WebhookPending {
+			next := d.NextAttempt
+			delivery.NextAttempt = &next
+		}
+
+		if d.LastError != "" {
+			lastError := d.LastError
+			delivery.LastError = &lastError
+		}
+
+		res.Deliveries = append(res.Deliveries, delivery)
+	}
+
+	v.store.IncrementStat(ctx, "api_webhook_status", 1)
+
+	return res, nil
+}
+
+func (v *v1API) SubdomainRotateToken(
+	ctx context.Context,
+	r v1.SubdomainRotateTokenRequestObject,
+) (v1.SubdomainRotateTokenResponseObject, error) {
+	if denied, err := v.authorize(ctx, r.SubdomainId, r.Body.Token); err != nil {
+		return nil, err
+	} else if denied != nil {
+		return v1.SubdomainRotateToken403JSONResponse(*denied), nil
+	}
+
+	gen, err := v.store.RotateSubdomainToken(ctx, r.SubdomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.recordActivity(ctx, r.SubdomainId, EventTokenRotated, ""); err != nil {
+		return nil, err
+	}
+
+	v.store.IncrementStat(ctx, "api_token_rotate", 1)
+
+	return v1.SubdomainRotateToken200JSONResponse{
+		Token: v.generateToken(r.SubdomainId, gen),
+	}, nil
+}
+
+func (v *v1API) ReportAbuse(
+	ctx context.Context,
+	r v1.ReportAbuseRequestObject,
+) (v1.ReportAbuseResponseObject, error) {
+	userIP, err := requestIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The endpoint is unauthenticated, so reports are limited per address
+	count, reset, err := v.store.IncrementRate(ctx, "abuse-"+userIP.String(), abuseReportWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > int64(v.abuseReportRate) {
+		v.store.IncrementStat(ctx, "api_abuse_report_limited", 1)
+
+		return v1.ReportAbuse429JSONResponse{
+			Body: v1.ErrorResponse{
+				Error:   "too-many-requests",
+				Message: "Too many reports have been sent, try again later.",
+			},
+			Headers: v1.ReportAbuse429ResponseHeaders{
+				RetryAfter: retryAfterSeconds(reset),
+			},
+		}, nil
+	}
+
+	reportID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := strings.TrimSuffix(strings.ToLower(r.Body.Domain), ".")
+
+	report := AbuseReport{
+		ID:         reportID,
+		Time:       time.Now(),
+		Domain:     domain,
+		Reason:     r.Body.Reason,
+		ReporterIP: userIP.String(),
+	}
+
+	if r.Body.Contact != nil {
+		report.Contact = *r.Body.Contact
+	}
+
+	if strings.HasSuffix(domain, "."+v.rootDomain) {
+		parts := strings.Split(strings.TrimSuffix(domain, "."+v.rootDomain), ".")
+
+		report.SubdomainID, err = resolveSubdomain(ctx, v.store, parts[len(parts)-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := v.store.AddAbuseReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	v.store.IncrementStat(ctx, "api_abuse_report", 1)
+
+	return v1.ReportAbuse200JSONResponse{
+		Id: reportID,
+	}, nil
+}
+
+func (v *v1API) authorize(ctx context.Context, id uuid.UUID, token string) (*v1.ErrorResponse, error) {
+	gen, err := v.store.GetSubdomainTokenGeneration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedToken := v.generateToken(id, gen)
+
+	if subtle.ConstantTimeCompare([]byte(expectedToken), []byte(token)) != 1 {
+		owned, err := v.ownedByRequestAccount(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !owned {
+			v.store.IncrementStat(ctx, "api_token_invalid", 1)
+
+			return &v1.ErrorResponse{
+				Error:   "invalid-token",
+				Message: "The provided token is not valid for the subdomain.",
+			}, nil
+		}
+	}
+
+	revoked, err := v.store.GetSubdomainRevocation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked != nil {
+		return &v1.ErrorResponse{
+			Error:   "subdomain-revoked",
+			Message: "The subdomain has been revoked.",
+		}, nil
+	}
+
+	block, err := v.store.GetSubdomainBlock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if block != nil {
+		v.store.IncrementStat(ctx, "api_subdomain_blocked", 1)
+
+		return &v1.ErrorResponse{
+			Error:   "subdomain-blocked",
+			Message: "The subdomain has been blocked.",
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *v1API) recordActivity(ctx context.Context, id uuid.UUID, event string, detail string) error {
+	userIP, err := requestIP(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = v.store.RecordActivity(ctx, id, Activity{
+		Time:   time.Now(),
+		Event:  event,
+		IP:     userIP.String(),
+		Detail: detail,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Vanity labels of idle subdomains expire
+	if err := v.store.RefreshVanityLabel(ctx, id, v.labelTTL); err != nil {
+		return err
+	}
+
+	// The change has already been made, so a queueing failure only loses the
+	// event
+	if err := v.webhooks.notify(ctx, id, event, detail); err != nil {
+		v.logger.Warnw("Failed to queue webhook", "id", id, "err", err)
+	}
+
+	return nil
+}
+
+// generateToken derives the control token of a token generation. The first
+// generation keeps the original derivation, so tokens issued before rotation
+// existed remain valid.
+func (v *v1API) generateToken(id uuid.UUID, gen int64) string {
+	buf := append([]byte(nil), v.tokenHash...)
 	buf = append(buf, id[:]...)
+
+	if gen > 0 {
+		buf = binary.BigEndian.AppendUint64(buf, uint64(gen))
+	}
+
 	hash := sha512.Sum512(buf)
 
 	return hex.EncodeToString(hash[:])
 }
+
+// retryAfterSeconds rounds up, as Retry-After only carries whole seconds.
+func retryAfterSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 1
+	}
+
+	return int((d + time.Second - 1) / time.Second)
+}
+
+func requestIP(ctx context.Context) (net.IP, error) {
+	r, ok := requestFromCtx(ctx)
+	if !ok {
+		return nil, errRequestMissingInCtx
+	}
+
+	// RealIP middleware stores the address without a port
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	userIP := net.ParseIP(ip)
+	if userIP == nil {
+		return nil, errInvalidRemoteAddr
+	}
+
+	return userIP, nil
+}
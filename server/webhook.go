@@ -0,0 +1,349 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	WebhookPending   = "pending"
+	WebhookDelivered = "delivered"
+	WebhookFailed    = "failed"
+
+	EventWebhookSet         = "webhook_set"
+	EventSubdomainBlocked   = "subdomain_blocked"
+	EventSubdomainUnblocked = "subdomain_unblocked"
+	EventTokenRotated       = "token_rotated"
+
+	webhookHistoryLimit = 20
+	webhookRetention    = 7 * 24 * time.Hour
+	webhookMaxAttempts  = 8
+	webhookRetryBase    = 10 * time.Second
+	webhookRetryMax     = time.Hour
+	webhookTimeout      = 10 * time.Second
+	webhookPoll         = 2 * time.Second
+	webhookBatch        = 50
+	webhookWorkers      = 10
+	webhookSecretBytes  = 32
+
+	// A batch shares one lease, so it must outlast every round of workers
+	// timing out, with room left for the store updates
+	webhookLease = 2 * webhookTimeout * webhookBatch / webhookWorkers
+)
+
+var (
+	webhookEvents = []string{
+		EventWebhookSet,
+		"acme_set",
+		"label_claimed",
+		"delegation_set",
+		"address_policy_set",
+		"service_set",
+		"caa_set",
+		EventSubdomainBlocked,
+		EventSubdomainUnblocked,
+		EventSubdomainRevoked,
+		EventTokenRotated,
+	}
+
+	errWebhookAddress = errors.New("webhook address is not public")
+	errWebhookRemoved = errors.New("webhook removed")
+)
+
+type Webhook struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events,omitempty"`
+}
+
+func (w *Webhook) wants(event string) bool {
+	if len(w.Events) == 0 || event == EventWebhookSet {
+		return true
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookDelivery struct {
+	ID          uuid.UUID `json:"id"`
+	SubdomainID uuid.UUID `json:"subdomain_id"` //nolint:tagliatelle
+	Event       string    `json:"event"`
+	Created     time.Time `json:"created"`
+	Payload     []byte    `json:"payload"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`         //nolint:tagliatelle
+	LastError   string    `json:"last_error,omitempty"` //nolint:tagliatelle
+}
+
+type webhookPayload struct {
+	ID          uuid.UUID `json:"id"`
+	Event       string    `json:"event"`
+	SubdomainID uuid.UUID `json:"subdomain_id"` //nolint:tagliatelle
+	Time        time.Time `json:"time"`
+	Detail      string    `json:"detail,omitempty"`
+}
+
+func parseWebhookURL(raw string, allowPrivate bool) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, errors.Errorf("url '%s' is not valid", raw)
+	}
+
+	if u.Scheme != "https" && !(allowPrivate && u.Scheme == "http") {
+		return nil, errors.New("url must use https")
+	}
+
+	if u.Hostname() == "" || u.User != nil {
+		return nil, errors.Errorf("url '%s' must have a host and no credentials", raw)
+	}
+
+	return u, nil
+}
+
+func validWebhookEvent(event string) bool {
+	for _, e := range webhookEvents {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, webhookSecretBytes)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// signWebhook returns the signature sent in X-DSDM-Signature, covering the
+// timestamp so captured deliveries cannot be replayed later.
+func signWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookSender queues subdomain events in the store and posts them to the
+// owner's webhook, retrying with backoff. Deliveries are leased rather than
+// removed while in flight, so a node failing mid delivery only delays them.
+type webhookSender struct {
+	logger *zap.SugaredLogger
+	store  Store
+	client *http.Client
+}
+
+func newWebhookSender(logger *zap.SugaredLogger, store Store, allowPrivate bool) *webhookSender {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+	}
+
+	// Checked after resolution so a hostname cannot be pointed at internal
+	// services once the URL has been accepted
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || classifyAddress(ip) != ClassPublic {
+				return errWebhookAddress
+			}
+
+			return nil
+		}
+	}
+
+	return &webhookSender{
+		logger: logger,
+		store:  store,
+		client: &http.Client{
+			Timeout: webhookTimeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: webhookTimeout,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     time.Minute,
+			},
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (w *webhookSender) notify(ctx context.Context, id uuid.UUID, event string, detail string) error {
+	hook, err := w.store.GetWebhook(ctx, id)
+	if err != nil || hook == nil || !hook.wants(event) {
+		return err
+	}
+
+	deliveryID, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:          deliveryID,
+		Event:       event,
+		SubdomainID: id,
+		Time:        now,
+		Detail:      detail,
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.store.AddWebhookDelivery(ctx, &WebhookDelivery{
+		ID:          deliveryID,
+		SubdomainID: id,
+		Event:       event,
+		Created:     now,
+		Payload:     payload,
+		Status:      WebhookPending,
+		NextAttempt: now,
+	})
+}
+
+func (w *webhookSender) run(ctx context.Context) {
+	for {
+		deliveries, err := w.store.ClaimWebhookDeliveries(ctx, time.Now(), webhookLease, webhookBatch)
+		if err != nil {
+			w.logger.Warnw("Failed to claim webhook deliveries", "err", err)
+		}
+
+		var group errgroup.Group
+
+		group.SetLimit(webhookWorkers)
+
+		for _, d := range deliveries {
+			d := d
+
+			group.Go(func() error {
+				w.deliver(ctx, d)
+
+				return nil
+			})
+		}
+
+		_ = group.Wait()
+
+		if len(deliveries) == webhookBatch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(webhookPoll):
+		}
+	}
+}
+
+func (w *webhookSender) deliver(ctx context.Context, d *WebhookDelivery) {
+	d.Attempts++
+
+	err := w.post(ctx, d)
+
+	switch {
+	case err == nil:
+		d.Status = WebhookDelivered
+		d.LastError = ""
+
+		w.store.IncrementStat(ctx, "webhook_delivered", 1)
+	case errors.Is(err, errWebhookRemoved) || d.Attempts >= webhookMaxAttempts:
+		d.Status = WebhookFailed
+		d.LastError = err.Error()
+
+		w.store.IncrementStat(ctx, "webhook_failed", 1)
+		w.logger.Infow("Webhook delivery failed", "id", d.ID, "subdomain", d.SubdomainID, "err", err)
+	default:
+		backoff := webhookRetryBase << (d.Attempts - 1)
+		if backoff > webhookRetryMax {
+			backoff = webhookRetryMax
+		}
+
+		d.NextAttempt = time.Now().Add(backoff)
+		d.LastError = err.Error()
+
+		w.store.IncrementStat(ctx, "webhook_retry", 1)
+	}
+
+	if err := w.store.UpdateWebhookDelivery(ctx, d); err != nil {
+		w.logger.Warnw("Failed to update webhook delivery", "id", d.ID, "err", err)
+	}
+}
+
+func (w *webhookSender) post(ctx context.Context, d *WebhookDelivery) error {
+	// The current registration is used so a changed URL or secret applies to
+	// queued deliveries too
+	hook, err := w.store.GetWebhook(ctx, d.SubdomainID)
+	if err != nil {
+		return err
+	}
+
+	if hook == nil || !hook.wants(d.Event) {
+		return errWebhookRemoved
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "dsdm/"+Version)
+	req.Header.Set("X-DSDM-Event", d.Event)
+	req.Header.Set("X-DSDM-Delivery", d.ID.String())
+	req.Header.Set("X-DSDM-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-DSDM-Signature", signWebhook(hook.Secret, timestamp, d.Payload))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
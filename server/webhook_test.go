@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSignWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		expected  string
+	}{
+		{
+			name:      "known vector",
+			secret:    "secret",
+			timestamp: 1700000000,
+			body:      `{"event":"webhook_set"}`,
+			expected:  "sha256=29a1e6d7a1fd781273bc1caa4fb2c7eff3e6b5cd1b8c928a9b23033760db351e",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signWebhook(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	sig := signWebhook("secret", 1700000000, []byte("body"))
+
+	if signWebhook("secret", 1700000001, []byte("body")) == sig {
+		t.Fatal("expected the timestamp to change the signature")
+	}
+
+	if signWebhook("other", 1700000000, []byte("body")) == sig {
+		t.Fatal("expected the secret to change the signature")
+	}
+}
+
+func TestWebhookWants(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []string
+		event    string
+		expected bool
+	}{
+		{name: "all events", event: EventSubdomainRevoked, expected: true},
+		{name: "subscribed", events: []string{"acme_set"}, event: "acme_set", expected: true},
+		{name: "not subscribed", events: []string{"acme_set"}, event: EventSubdomainRevoked},
+		{name: "webhook set always sent", events: []string{"acme_set"}, event: EventWebhookSet, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Webhook{Events: tt.events}
+
+			if got := w.wants(tt.event); got != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClaimWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	lease := time.Minute
+
+	tests := []struct {
+		name     string
+		claims   []time.Time
+		status   string
+		expected []int
+	}{
+		{
+			name:     "leased until expiry",
+			claims:   []time.Time{now, now.Add(time.Second), now.Add(lease + time.Second)},
+			status:   WebhookPending,
+			expected: []int{1, 0, 1},
+		},
+		{
+			name:     "not yet due",
+			claims:   []time.Time{now.Add(-time.Second)},
+			status:   WebhookPending,
+			expected: []int{0},
+		},
+		{
+			name:     "finished",
+			claims:   []time.Time{now, now.Add(lease + time.Second)},
+			status:   WebhookDelivered,
+			expected: []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			d := &WebhookDelivery{
+				ID:          uuid.New(),
+				SubdomainID: testID,
+				Event:       EventWebhookSet,
+				Created:     now,
+				Status:      WebhookPending,
+				NextAttempt: now,
+			}
+
+			if err := store.AddWebhookDelivery(ctx, d); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.status != WebhookPending {
+				d.Status = tt.status
+
+				if err := store.UpdateWebhookDelivery(ctx, d); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for i, at := range tt.claims {
+				claimed, err := store.ClaimWebhookDeliveries(ctx, at, lease, webhookBatch)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(claimed) != tt.expected[i] {
+					t.Fatalf("claim %d: expected %d deliveries, got %d", i, tt.expected[i], len(claimed))
+				}
+			}
+		})
+	}
+}
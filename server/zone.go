@@ -0,0 +1,483 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultSOARefresh = 3600
+	defaultSOARetry   = 600
+	defaultSOAExpire  = 604800
+	defaultSOAMinimum = 60
+
+	zoneTTL        = 300
+	tsigFudge      = 300
+	notifyTimeout  = 5 * time.Second
+	notifyAttempts = 3
+)
+
+var errNotifyRejected = errors.New("notify rejected")
+
+// staticZone is the operator managed portion of the zone, which is the only
+// part offered to secondaries, along with the settings a reload may change.
+type staticZone struct {
+	serial      uint32
+	hash        []byte
+	records     map[string]StaticRecord
+	nameservers []string
+	soa         SOAConfig
+	reserved    map[string]struct{}
+	secondaries []Secondary
+	tsigKeys    map[string]TSIGKey
+}
+
+func buildStaticZone(cfg Config) *staticZone {
+	root := dns.Fqdn(strings.ToLower(cfg.RootDomain))
+
+	z := &staticZone{
+		records:     cfg.StaticRecords,
+		nameservers: make([]string, 0, len(cfg.Nameservers)),
+		soa:         cfg.SOA,
+		reserved:    map[string]struct{}{},
+		secondaries: cfg.Secondaries,
+		tsigKeys:    map[string]TSIGKey{},
+	}
+
+	for _, ns := range cfg.Nameservers {
+		z.nameservers = append(z.nameservers, dns.Fqdn(strings.ToLower(ns)))
+	}
+
+	for _, label := range cfg.ReservedLabels {
+		z.reserved[strings.ToLower(label)] = struct{}{}
+	}
+
+	for name := range cfg.StaticRecords {
+		for _, label := range strings.Split(name, ".") {
+			z.reserved[strings.ToLower(label)] = struct{}{}
+		}
+	}
+
+	for _, key := range cfg.TSIGKeys {
+		z.tsigKeys[dns.Fqdn(strings.ToLower(key.Name))] = key
+	}
+
+	if z.soa.MName == "" {
+		if len(z.nameservers) > 0 {
+			z.soa.MName = z.nameservers[0]
+		} else {
+			z.soa.MName = "ns1." + root
+		}
+	}
+
+	if z.soa.RName == "" {
+		z.soa.RName = "hostmaster." + root
+	}
+
+	z.soa.MName = dns.Fqdn(z.soa.MName)
+	z.soa.RName = dns.Fqdn(z.soa.RName)
+
+	if z.soa.Refresh == 0 {
+		z.soa.Refresh = defaultSOARefresh
+	}
+
+	if z.soa.Retry == 0 {
+		z.soa.Retry = defaultSOARetry
+	}
+
+	if z.soa.Expire == 0 {
+		z.soa.Expire = defaultSOAExpire
+	}
+
+	if z.soa.Minimum == 0 {
+		z.soa.Minimum = defaultSOAMinimum
+	}
+
+	// Map keys are sorted when marshalled, so equal zones hash equally
+	data, _ := json.Marshal([]any{z.records, z.nameservers, z.soa})
+	hash := sha256.Sum256(data)
+	z.hash = hash[:]
+
+	return z
+}
+
+// loadStaticZone builds the zone from cfg, taking its serial from the store so
+// that it only changes with the zone contents and is shared across instances.
+func (s *Server) loadStaticZone(cfg Config, prev *staticZone) *staticZone {
+	z := buildStaticZone(cfg)
+
+	//nolint:gosec
+	serial, err := s.store.ZoneSerial(context.Background(), hex.EncodeToString(z.hash), uint32(time.Now().Unix()))
+	if err == nil {
+		z.serial = serial
+
+		return z
+	}
+
+	s.logger.Errorw("Failed to load zone serial", "err", err)
+
+	switch {
+	case prev == nil:
+		//nolint:gosec
+		z.serial = uint32(time.Now().Unix())
+	case bytes.Equal(prev.hash, z.hash):
+		z.serial = prev.serial
+	default:
+		z.serial = prev.serial + 1
+	}
+
+	return z
+}
+
+func (z *staticZone) soaRecord(root string) *dns.SOA {
+	return &dns.SOA{
+		Hdr:     dns.RR_Header{Name: root, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: zoneTTL},
+		Ns:      z.soa.MName,
+		Mbox:    z.soa.RName,
+		Serial:  z.serial,
+		Refresh: z.soa.Refresh,
+		Retry:   z.soa.Retry,
+		Expire:  z.soa.Expire,
+		Minttl:  z.soa.Minimum,
+	}
+}
+
+func (z *staticZone) nsRecords(root string) []dns.RR {
+	records := make([]dns.RR, 0, len(z.nameservers))
+
+	for _, ns := range z.nameservers {
+		records = append(records, &dns.NS{
+			Hdr: dns.RR_Header{Name: root, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: zoneTTL},
+			Ns:  ns,
+		})
+	}
+
+	return records
+}
+
+// transferRecords returns the full static zone in transfer order, without the
+// closing SOA.
+func (z *staticZone) transferRecords(root string) []dns.RR {
+	records := []dns.RR{z.soaRecord(root)}
+	records = append(records, z.nsRecords(root)...)
+
+	for name, static := range z.records {
+		owner := root
+		if name != "@" {
+			owner = name + "." + root
+		}
+
+		for _, a := range static.A {
+			ip := net.ParseIP(a).To4()
+			if ip == nil {
+				continue
+			}
+
+			records = append(records, &dns.A{
+				Hdr: dns.RR_Header{Name: owner, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 0},
+				A:   ip,
+			})
+		}
+	}
+
+	return records
+}
+
+func (z *staticZone) tsigSecrets() map[string]string {
+	secrets := map[string]string{}
+
+	for name, key := range z.tsigKeys {
+		secrets[name] = key.Secret
+	}
+
+	return secrets
+}
+
+func (z *staticZone) tsigAlgorithm(name string) string {
+	if key, ok := z.tsigKeys[name]; ok && key.Algorithm != "" {
+		return dns.Fqdn(strings.ToLower(key.Algorithm))
+	}
+
+	return dns.HmacSHA256
+}
+
+// zoneTSIG signs and verifies with the keys of the current zone, so reloaded
+// keys apply to listeners that are already running.
+type zoneTSIG struct {
+	zone *atomic.Pointer[staticZone]
+}
+
+func (t zoneTSIG) Generate(msg []byte, rr *dns.TSIG) ([]byte, error) {
+	key, ok := t.zone.Load().tsigKeys[strings.ToLower(rr.Hdr.Name)]
+	if !ok {
+		return nil, dns.ErrSecret
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(key.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var h hash.Hash
+
+	switch dns.CanonicalName(rr.Algorithm) {
+	case dns.HmacSHA1:
+		h = hmac.New(sha1.New, secret)
+	case dns.HmacSHA224:
+		h = hmac.New(sha256.New224, secret)
+	case dns.HmacSHA256:
+		h = hmac.New(sha256.New, secret)
+	case dns.HmacSHA384:
+		h = hmac.New(sha512.New384, secret)
+	case dns.HmacSHA512:
+		h = hmac.New(sha512.New, secret)
+	default:
+		return nil, dns.ErrKeyAlg
+	}
+
+	h.Write(msg)
+
+	return h.Sum(nil), nil
+}
+
+func (t zoneTSIG) Verify(msg []byte, rr *dns.TSIG) error {
+	sum, err := t.Generate(msg, rr)
+	if err != nil {
+		return err
+	}
+
+	mac, err := hex.DecodeString(rr.MAC)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(sum, mac) {
+		return dns.ErrSig
+	}
+
+	return nil
+}
+
+func (s *Server) newDNSServer(addr string, network string) *dns.Server {
+	// Without a provider the library skips TSIG verification entirely, so
+	// every listener must have one to stop unverified transfers
+	return &dns.Server{
+		Addr:         addr,
+		Net:          network,
+		Handler:      s,
+		TsigProvider: zoneTSIG{zone: &s.zone},
+	}
+}
+
+// Reload swaps in the static records, nameservers, SOA, reserved labels,
+// secondaries and TSIG keys from cfg and notifies secondaries when the zone
+// changed.
+func (s *Server) Reload(cfg Config) {
+	prev := s.zone.Load()
+	next := s.loadStaticZone(cfg, prev)
+
+	s.zone.Store(next)
+
+	if next.serial == prev.serial {
+		s.logger.Infow("Static zone unchanged", "serial", next.serial)
+
+		return
+	}
+
+	s.logger.Infow("Static zone reloaded", "serial", next.serial)
+
+	go s.notifySecondaries(next)
+}
+
+func (z *staticZone) secondaryFor(addr net.Addr, keyName string) *Secondary {
+	var ip net.IP
+
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ip = a.IP
+	case *net.UDPAddr:
+		ip = a.IP
+	default:
+		return nil
+	}
+
+	for i, secondary := range z.secondaries {
+		if !strings.EqualFold(dns.Fqdn(secondary.Key), keyName) {
+			continue
+		}
+
+		if strings.Contains(secondary.Address, "/") {
+			_, n, err := net.ParseCIDR(secondary.Address)
+			if err == nil && n.Contains(ip) {
+				return &z.secondaries[i]
+			}
+		} else if peer := net.ParseIP(secondary.Address); peer != nil && peer.Equal(ip) {
+			return &z.secondaries[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) serveTransfer(w dns.ResponseWriter, r *dns.Msg) {
+	q := r.Question[0]
+	root := dns.Fqdn(strings.ToLower(s.cfg.RootDomain))
+
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	tsig := r.IsTsig()
+	z := s.zone.Load()
+
+	switch {
+	case !strings.EqualFold(q.Name, root):
+		m.Rcode = dns.RcodeNotAuth
+	case tsig == nil || w.TsigStatus() != nil:
+		s.store.IncrementStat(context.Background(), "dns_transfer_denied", 1)
+
+		m.Rcode = dns.RcodeNotAuth
+	case z.secondaryFor(w.RemoteAddr(), strings.ToLower(tsig.Hdr.Name)) == nil:
+		s.store.IncrementStat(context.Background(), "dns_transfer_denied", 1)
+
+		m.Rcode = dns.RcodeRefused
+	}
+
+	if m.Rcode != dns.RcodeSuccess {
+		s.logger.Warnw("DNS Transfer Refused", "remote", w.RemoteAddr(), "rcode", dns.RcodeToString[m.Rcode])
+
+		if tsig != nil && w.TsigStatus() == nil {
+			m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsigFudge, time.Now().Unix())
+		}
+
+		if err := w.WriteMsg(m); err != nil {
+			s.transferError(r, err)
+		}
+
+		return
+	}
+
+	soa := z.soaRecord(root)
+
+	_, udp := w.RemoteAddr().(*net.UDPAddr)
+
+	// A secondary that is current, or asked over UDP, only needs the SOA
+	if q.Qtype == dns.TypeIXFR && (udp || ixfrCurrent(r, z.serial)) {
+		m.Authoritative = true
+		m.Answer = []dns.RR{soa}
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsigFudge, time.Now().Unix())
+
+		if err := w.WriteMsg(m); err != nil {
+			s.transferError(r, err)
+		}
+
+		return
+	}
+
+	if udp {
+		m.Rcode = dns.RcodeRefused
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsigFudge, time.Now().Unix())
+
+		if err := w.WriteMsg(m); err != nil {
+			s.transferError(r, err)
+		}
+
+		return
+	}
+
+	s.logger.Infow("DNS Transfer", "remote", w.RemoteAddr(), "type", dns.TypeToString[q.Qtype], "serial", z.serial)
+	s.store.IncrementStat(context.Background(), "dns_transfer", 1)
+
+	// Incremental history is not kept, so IXFR is answered with the full zone
+	records := append(z.transferRecords(root), soa)
+
+	ch := make(chan *dns.Envelope, 1)
+	ch <- &dns.Envelope{RR: records}
+	close(ch)
+
+	tr := new(dns.Transfer)
+	if err := tr.Out(w, r, ch); err != nil {
+		s.transferError(r, err)
+	}
+
+	_ = w.Close()
+}
+
+func ixfrCurrent(r *dns.Msg, serial uint32) bool {
+	for _, rr := range r.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa.Serial == serial
+		}
+	}
+
+	return false
+}
+
+func (s *Server) transferError(r *dns.Msg, err error) {
+	s.logger.WithOptions(zap.AddStacktrace(zapcore.NewNopCore())).Errorw(
+		"DNS Transfer Error",
+		"request_id", r.Id,
+		"err", err,
+	)
+}
+
+func (s *Server) notifySecondaries(z *staticZone) {
+	root := dns.Fqdn(strings.ToLower(s.cfg.RootDomain))
+	secrets := z.tsigSecrets()
+
+	for _, secondary := range z.secondaries {
+		if secondary.Notify == "" {
+			continue
+		}
+
+		key := dns.Fqdn(strings.ToLower(secondary.Key))
+
+		m := new(dns.Msg)
+		m.SetNotify(root)
+		m.Answer = []dns.RR{z.soaRecord(root)}
+		m.SetTsig(key, z.tsigAlgorithm(key), tsigFudge, time.Now().Unix())
+
+		c := &dns.Client{
+			Net:        "udp",
+			Timeout:    notifyTimeout,
+			TsigSecret: secrets,
+		}
+
+		var err error
+
+		for attempt := 0; attempt < notifyAttempts; attempt++ {
+			var res *dns.Msg
+
+			res, _, err = c.Exchange(m, secondary.Notify)
+			if err == nil && res.Rcode != dns.RcodeSuccess {
+				err = errNotifyRejected
+			}
+
+			if err == nil {
+				break
+			}
+		}
+
+		if err != nil {
+			s.logger.Warnw("Failed to notify secondary", "secondary", secondary.Notify, "err", err)
+		} else {
+			s.logger.Infow("Notified secondary", "secondary", secondary.Notify, "serial", z.serial)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	testTSIGName   = "transfer."
+	testTSIGSecret = "c2VjcmV0c2VjcmV0c2VjcmV0c2VjcmV0"
+)
+
+func startTestDNS(t *testing.T, s *Server) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+
+	srv := s.newDNSServer("", "tcp")
+	srv.Listener = ln
+	srv.NotifyStartedFunc = func() { close(started) }
+
+	go func() {
+		_ = srv.ActivateAndServe()
+	}()
+
+	t.Cleanup(func() {
+		_ = srv.Shutdown()
+	})
+
+	<-started
+
+	return ln.Addr().String()
+}
+
+func TestServeTransfer(t *testing.T) {
+	tests := []struct {
+		name      string
+		qname     string
+		key       string
+		secret    string
+		secondary string
+		rcode     int
+	}{
+		{
+			name:      "unsigned",
+			qname:     testRoot,
+			secondary: "127.0.0.1",
+			rcode:     dns.RcodeNotAuth,
+		},
+		{
+			name:      "wrong secret",
+			qname:     testRoot,
+			key:       testTSIGName,
+			secret:    "d3Jvbmd3cm9uZ3dyb25nd3Jvbmd3cm9uZw==",
+			secondary: "127.0.0.1",
+			rcode:     dns.RcodeNotAuth,
+		},
+		{
+			name:      "unknown key",
+			qname:     testRoot,
+			key:       "other.",
+			secret:    testTSIGSecret,
+			secondary: "127.0.0.1",
+			rcode:     dns.RcodeNotAuth,
+		},
+		{
+			name:      "not a secondary",
+			qname:     testRoot,
+			key:       testTSIGName,
+			secret:    testTSIGSecret,
+			secondary: "192.0.2.1",
+			rcode:     dns.RcodeRefused,
+		},
+		{
+			name:      "outside zone",
+			qname:     "example.com.",
+			key:       testTSIGName,
+			secret:    testTSIGSecret,
+			secondary: "127.0.0.1",
+			rcode:     dns.RcodeNotAuth,
+		},
+		{
+			name:      "allowed",
+			qname:     testRoot,
+			key:       testTSIGName,
+			secret:    testTSIGSecret,
+			secondary: "127.0.0.0/8",
+			rcode:     dns.RcodeSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestServer(t, Config{
+				Nameservers: []string{"ns1.dyn.test"},
+				TSIGKeys:    []TSIGKey{{Name: testTSIGName, Secret: testTSIGSecret}},
+				Secondaries: []Secondary{{Address: tt.secondary, Key: testTSIGName}},
+			})
+
+			addr := startTestDNS(t, s)
+
+			r := new(dns.Msg)
+			r.SetAxfr(tt.qname)
+
+			client := &dns.Client{Net: "tcp"}
+
+			if tt.key != "" {
+				r.SetTsig(tt.key, dns.HmacSHA256, tsigFudge, 0)
+				client.TsigSecret = map[string]string{tt.key: tt.secret}
+			}
+
+			m, _, err := client.Exchange(r, addr)
+			if errors.Is(err, dns.ErrAuth) && tt.rcode == dns.RcodeNotAuth {
+				// The library rejects signed NOTAUTH responses before returning them
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.Rcode != tt.rcode {
+				t.Fatalf("expected rcode %s, got %s", dns.RcodeToString[tt.rcode], dns.RcodeToString[m.Rcode])
+			}
+
+			if tt.rcode != dns.RcodeSuccess {
+				if len(m.Answer) != 0 {
+					t.Fatalf("expected no records, got %d", len(m.Answer))
+				}
+
+				return
+			}
+
+			if len(m.Answer) == 0 || m.Answer[0].Header().Rrtype != dns.TypeSOA {
+				t.Fatalf("expected the transfer to start with the SOA, got %v", m.Answer)
+			}
+		})
+	}
+}